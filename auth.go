@@ -2,7 +2,7 @@ package navitia
 
 import (
 	"net/http"
-	"path"
+	"sync"
 	"time"
 
 	"github.com/aabizri/navitia/types"
@@ -20,7 +20,7 @@ const (
 
 	defaultAPIURL = DefaultAPIProtocol + "://" + DefaultAPIHostname + "/" + DefaultAPIVersion
 
-	// Maximum size of response in bytes
+	// Default maximum size of response in bytes
 	// 10 megabytes
 	maxSize int64 = 10 * (1000 * 1000)
 )
@@ -32,26 +32,76 @@ type Session struct {
 	APIKey string
 	APIURL string
 
+	// Retry is the policy used to retry requests failing because of a transient error
+	// If it is nil, requests aren't retried
+	Retry *RetryPolicy
+
+	// Limiter limits the rate of the requests made by the session and its scopes
+	// If it is nil, requests aren't limited
+	Limiter *Limiter
+
+	// Cache stores the responses of the endpoints which rarely change, such as regions, places or referential objects
+	// If it is nil, responses aren't cached
+	Cache Cache
+
+	// CacheTTLs are the durations for which the responses of each endpoint are cached, such as "places" or "lines"
+	// If it is nil, DefaultCacheTTLs are used
+	CacheTTLs map[string]time.Duration
+
+	// Coalesce enables the coalescing of identical concurrent calls: while a request is in flight, the calls to the same URL with the same headers wait for it and share its response.
+	// Each call still decodes its own copy of the results.
+	// Streamed calls aren't coalesced.
+	Coalesce bool
+
+	// Middlewares wrap every request made by the session and its scopes, the first one being the outermost
+	Middlewares []Middleware
+
+	// UserAgent is sent with each request, if it isn't empty
+	UserAgent string
+
+	// MaxResponseSize is the maximum size of a response in bytes
+	// If it is 0, the default of 10MB is used
+	MaxResponseSize int64
+
+	// MaxResponseSizes are the maximum sizes of the responses of each endpoint, such as "coverage", taking precedence over MaxResponseSize
+	MaxResponseSizes map[string]int64
+
+	// Logger logs each attempt of each request, if it isn't nil
+	Logger Logger
+
 	client  *http.Client
 	created time.Time
+
+	// quota is the last quota reported by the server, guarded by quotaMu
+	quota   Quota
+	quotaMu sync.Mutex
+
+	// flights are the in-flight requests, shared when Coalesce is enabled
+	flights flightGroup
+
+	// locations are the timezones of the regions, as learnt from the responses, guarded by locationsMu
+	locations   map[types.ID]*time.Location
+	locationsMu sync.Mutex
 }
 
 // New creates a new session given an API Key.
-// It acts as a convenience wrapper to NewCustom.
+// It acts as a convenience wrapper to NewSession.
 //
-// Warning: No Timeout is indicated in the default http client, and as such, it is strongly advised to use NewCustom with a custom *http.Client !
+// Warning: No Timeout is indicated in the default http client, and as such, it is strongly advised to use NewSession, which sets one by default !
 func New(key string) (*Session, error) {
-	return NewCustom(key, path.Clean(defaultAPIURL), defaultClient)
+	return NewCustom(key, defaultAPIURL, defaultClient)
 }
 
-// NewCustom creates a custom new session given an API key, URL to api base & http client
+// NewCustom creates a custom new session given an API key, URL to api base & http client.
+// It acts as a convenience wrapper to NewSession.
+//
+// For compatibility, the URL is used as-is and no User-Agent is sent, contrary to NewSession.
 func NewCustom(key string, url string, client *http.Client) (*Session, error) {
-	return &Session{
-		APIKey:  key,
-		APIURL:  url,
-		created: time.Now(),
-		client:  client,
-	}, nil
+	opts := []Option{withRawBaseURL(url), WithUserAgent("")}
+	if client != nil {
+		opts = append(opts, WithHTTPClient(client))
+	}
+	return NewSession(key, opts...)
 }
 
 // A Scope is a coverage-scoped question, allowing you to query information about a specific region.
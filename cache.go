@@ -0,0 +1,209 @@
+package navitia
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aabizri/navitia/types"
+)
+
+// A Cache stores the raw bodies of the responses, keyed on the final request URL.
+// The key also holds a hash of the session's API key and of the call's headers, so that sessions sharing a cache don't serve each other's responses.
+//
+// Implementations must be safe for concurrent use, see MemoryCache & DiskCache.
+type Cache interface {
+	// Get returns the body stored for the key, if there is one and it hasn't expired
+	Get(key string) (body []byte, ok bool)
+
+	// Set stores the body for the key, for the given duration
+	Set(key string, body []byte, ttl time.Duration)
+}
+
+// DefaultCacheTTLs are the durations for which the responses of each endpoint are cached, when the Session doesn't specify any.
+//
+// Endpoints not listed, such as journeys or departures, aren't cached.
+var DefaultCacheTTLs = map[string]time.Duration{
+	regionEndpoint:          24 * time.Hour,
+	coordsEndpoint:          24 * time.Hour,
+	placesEndpoint:          time.Hour,
+	placesNearbyEndpoint:    time.Hour,
+	ptObjectsEndpoint:       time.Hour,
+	linesEndpoint:           time.Hour,
+	routesEndpoint:          time.Hour,
+	stopAreasEndpoint:       time.Hour,
+	stopPointsEndpoint:      time.Hour,
+	networksEndpoint:        time.Hour,
+	companiesEndpoint:       time.Hour,
+	physicalModesEndpoint:   24 * time.Hour,
+	commercialModesEndpoint: 24 * time.Hour,
+}
+
+// requestKey returns the key identifying the response to a request URL sent with the given headers: the URL followed by a hash of the session's API key & of the headers.
+//
+// Sessions with different API keys may have access to different coverages, and headers set by middlewares, such as Accept-Language, may change the response.
+func (s *Session) requestKey(url string, header http.Header) string {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	h := sha256.New()
+	io.WriteString(h, s.APIKey)
+	for _, name := range names {
+		for _, value := range header[name] {
+			fmt.Fprintf(h, "\x00%s: %s", http.CanonicalHeaderKey(name), value)
+		}
+	}
+	return url + "#" + hex.EncodeToString(h.Sum(nil)[:16])
+}
+
+// cacheTTL returns the duration for which the response to a request URL can be cached, 0 meaning it can't be.
+//
+// Requests for realtime data are never cached.
+func (s *Session) cacheTTL(rawURL string) time.Duration {
+	if s.Cache == nil {
+		return 0
+	}
+
+	ttls := s.CacheTTLs
+	if ttls == nil {
+		ttls = DefaultCacheTTLs
+	}
+	ttl := ttls[endpointOf(rawURL)]
+	if ttl <= 0 {
+		return 0
+	}
+
+	// Bypass realtime requests
+	if u, err := url.Parse(rawURL); err == nil && u.Query().Get("data_freshness") == string(types.DataFreshnessRealTime) {
+		return 0
+	}
+
+	return ttl
+}
+
+// responseTTL restricts the ttl of a response according to its HTTP caching headers:
+// Cache-Control no-store, no-cache & private forbid caching, while its max-age and the Expires header cap the ttl.
+func responseTTL(ttl time.Duration, header http.Header, now time.Time) time.Duration {
+	if cc := header.Get("Cache-Control"); cc != "" {
+		for _, directive := range strings.Split(cc, ",") {
+			directive = strings.ToLower(strings.TrimSpace(directive))
+			switch {
+			case directive == "no-store" || directive == "no-cache" || directive == "private":
+				return 0
+			case strings.HasPrefix(directive, "max-age="):
+				seconds, err := strconv.ParseInt(strings.TrimPrefix(directive, "max-age="), 10, 64)
+				if err != nil {
+					continue
+				}
+				if maxAge := time.Duration(seconds) * time.Second; maxAge < ttl {
+					ttl = maxAge
+				}
+				// max-age takes precedence over Expires
+				return ttl
+			}
+		}
+	}
+
+	if expires := header.Get("Expires"); expires != "" {
+		date, err := http.ParseTime(expires)
+		if err != nil {
+			// An invalid Expires means already expired
+			return 0
+		}
+		if untilExpiry := date.Sub(now); untilExpiry < ttl {
+			ttl = untilExpiry
+		}
+	}
+
+	if ttl < 0 {
+		return 0
+	}
+	return ttl
+}
+
+// A MemoryCache is an in-memory Cache, evicting the least recently used entries once it is full.
+type MemoryCache struct {
+	size int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List // front is most recently used
+}
+
+// memoryCacheEntry is an entry of a MemoryCache
+type memoryCacheEntry struct {
+	key     string
+	body    []byte
+	expires time.Time
+}
+
+// NewMemoryCache creates a MemoryCache holding at most size entries
+func NewMemoryCache(size int) *MemoryCache {
+	return &MemoryCache{
+		size:    size,
+		entries: make(map[string]*list.Element, size),
+		lru:     list.New(),
+	}
+}
+
+// Get returns the body stored for the key, if there is one and it hasn't expired
+func (mc *MemoryCache) Get(key string) ([]byte, bool) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	elem, ok := mc.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*memoryCacheEntry)
+	if time.Now().After(entry.expires) {
+		mc.lru.Remove(elem)
+		delete(mc.entries, key)
+		return nil, false
+	}
+	mc.lru.MoveToFront(elem)
+	return entry.body, true
+}
+
+// Set stores the body for the key, for the given duration, evicting the least recently used entry if the cache is full
+func (mc *MemoryCache) Set(key string, body []byte, ttl time.Duration) {
+	if mc.size <= 0 || ttl <= 0 {
+		return
+	}
+
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	entry := &memoryCacheEntry{key: key, body: body, expires: time.Now().Add(ttl)}
+	if elem, ok := mc.entries[key]; ok {
+		elem.Value = entry
+		mc.lru.MoveToFront(elem)
+		return
+	}
+
+	mc.entries[key] = mc.lru.PushFront(entry)
+	for mc.lru.Len() > mc.size {
+		oldest := mc.lru.Back()
+		mc.lru.Remove(oldest)
+		delete(mc.entries, oldest.Value.(*memoryCacheEntry).key)
+	}
+}
+
+// Len returns the number of entries in the cache, including the expired ones not yet evicted
+func (mc *MemoryCache) Len() int {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	return mc.lru.Len()
+}
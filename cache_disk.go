@@ -0,0 +1,83 @@
+package navitia
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// A DiskCache is a Cache storing each entry in a file of a directory, allowing the cache to persist across runs.
+//
+// Expired entries are removed when they are looked up.
+type DiskCache struct {
+	dir string
+}
+
+// NewDiskCache creates a DiskCache in the given directory, creating it if needed
+func NewDiskCache(dir string) (*DiskCache, error) {
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't create cache directory %s", dir)
+	}
+	return &DiskCache{dir: dir}, nil
+}
+
+// path returns the path of the file of an entry
+func (dc *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(dc.dir, hex.EncodeToString(sum[:]))
+}
+
+// Get returns the body stored for the key, if there is one and it hasn't expired
+//
+// Each file starts with the expiry date, as a big-endian unix timestamp in nanoseconds, followed by the body.
+func (dc *DiskCache) Get(key string) ([]byte, bool) {
+	path := dc.path(key)
+	content, err := ioutil.ReadFile(path)
+	if err != nil || len(content) < 8 {
+		return nil, false
+	}
+
+	expires := time.Unix(0, int64(binary.BigEndian.Uint64(content[:8])))
+	if time.Now().After(expires) {
+		os.Remove(path)
+		return nil, false
+	}
+	return content[8:], true
+}
+
+// Set stores the body for the key, for the given duration
+//
+// Errors are ignored, as a failure to cache shouldn't fail the request.
+func (dc *DiskCache) Set(key string, body []byte, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+
+	content := make([]byte, 8+len(body))
+	binary.BigEndian.PutUint64(content[:8], uint64(time.Now().Add(ttl).UnixNano()))
+	copy(content[8:], body)
+
+	// Write to a temporary file then rename it, so that concurrent readers never see a partial entry
+	tmp, err := ioutil.TempFile(dc.dir, "tmp-")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(content)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return
+	}
+	if os.Rename(tmp.Name(), dc.path(key)) != nil {
+		os.Remove(tmp.Name())
+	}
+}
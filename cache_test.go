@@ -0,0 +1,226 @@
+package navitia
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aabizri/navitia/types"
+)
+
+func Test_endpointOf(t *testing.T) {
+	known := map[string]string{
+		"https://api.navitia.io/v1/coverage":                                          "coverage",
+		"https://api.navitia.io/v1/coverage/fr-idf":                                   "coverage",
+		"https://api.navitia.io/v1/coverage/fr-idf/places?q=avenue":                   "places",
+		"https://api.navitia.io/v1/coverage/fr-idf/lines/line:OIF:100110004:4OIF439":  "lines",
+		"https://api.navitia.io/v1/coverage/fr-idf/lines/line:A/stop_areas?depth=2":   "stop_areas",
+		"https://api.navitia.io/v1/coverage/fr-idf/stop_areas/stop_area:A/departures": "departures",
+		"https://api.navitia.io/v1/coords/2.377;48.847/places_nearby":                 "places_nearby",
+		"https://api.navitia.io/v1/journeys?from=2.377%3B48.847":                      "journeys",
+		"https://api.navitia.io/v1/unknown":                                           "",
+	}
+	for in, expected := range known {
+		if got := endpointOf(in); got != expected {
+			t.Errorf("endpointOf(%s): expected %q, got %q", in, expected, got)
+		}
+	}
+}
+
+func Test_responseTTL(t *testing.T) {
+	now := time.Date(2017, 4, 27, 17, 0, 0, 0, time.UTC)
+	tests := []struct {
+		header   http.Header
+		expected time.Duration
+	}{
+		{http.Header{}, time.Hour},
+		{http.Header{"Cache-Control": {"no-store"}}, 0},
+		{http.Header{"Cache-Control": {"public, no-cache"}}, 0},
+		{http.Header{"Cache-Control": {"public, max-age=60"}}, time.Minute},
+		{http.Header{"Cache-Control": {"max-age=86400"}}, time.Hour},
+		{http.Header{"Cache-Control": {"max-age=60"}, "Expires": {"Thu, 27 Apr 2017 17:10:00 GMT"}}, time.Minute},
+		{http.Header{"Expires": {"Thu, 27 Apr 2017 17:10:00 GMT"}}, 10 * time.Minute},
+		{http.Header{"Expires": {"Thu, 27 Apr 2017 16:00:00 GMT"}}, 0},
+		{http.Header{"Expires": {"0"}}, 0},
+	}
+	for _, test := range tests {
+		if got := responseTTL(time.Hour, test.header, now); got != test.expected {
+			t.Errorf("responseTTL with %v: expected %v, got %v", test.header, test.expected, got)
+		}
+	}
+}
+
+func Test_MemoryCache(t *testing.T) {
+	// Declare this test to be run in parallel
+	t.Parallel()
+
+	mc := NewMemoryCache(2)
+	mc.Set("a", []byte("a"), time.Hour)
+	mc.Set("b", []byte("b"), time.Hour)
+
+	// Use "a", so that "b" is the least recently used
+	if body, ok := mc.Get("a"); !ok || string(body) != "a" {
+		t.Fatalf("expected a hit for a, got %q (%v)", body, ok)
+	}
+	mc.Set("c", []byte("c"), time.Hour)
+	if _, ok := mc.Get("b"); ok {
+		t.Errorf("expected b to be evicted")
+	}
+	if _, ok := mc.Get("a"); !ok {
+		t.Errorf("expected a to be kept")
+	}
+	if mc.Len() != 2 {
+		t.Errorf("expected 2 entries, got %d", mc.Len())
+	}
+
+	// Expiry
+	mc.Set("d", []byte("d"), time.Nanosecond)
+	time.Sleep(time.Millisecond)
+	if _, ok := mc.Get("d"); ok {
+		t.Errorf("expected d to be expired")
+	}
+}
+
+func Test_DiskCache(t *testing.T) {
+	// Declare this test to be run in parallel
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "navitia-cache")
+	if err != nil {
+		t.Fatalf("couldn't create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	dc, err := NewDiskCache(dir)
+	if err != nil {
+		t.Fatalf("error in NewDiskCache: %v", err)
+	}
+
+	dc.Set("https://api.navitia.io/v1/coverage", []byte(`{"regions": []}`), time.Hour)
+	if body, ok := dc.Get("https://api.navitia.io/v1/coverage"); !ok || string(body) != `{"regions": []}` {
+		t.Errorf("expected a hit, got %q (%v)", body, ok)
+	}
+	if _, ok := dc.Get("https://api.navitia.io/v1/coverage/fr-idf"); ok {
+		t.Errorf("expected a miss for an unknown key")
+	}
+
+	dc.Set("expired", []byte("expired"), time.Nanosecond)
+	time.Sleep(time.Millisecond)
+	if _, ok := dc.Get("expired"); ok {
+		t.Errorf("expected the entry to be expired")
+	}
+}
+
+func Test_Session_Cache(t *testing.T) {
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		fmt.Fprint(w, `{"places": [], "departures": []}`)
+	}))
+	defer ts.Close()
+
+	s, err := NewCustom("", ts.URL, http.DefaultClient)
+	if err != nil {
+		t.Fatalf("error while creating session: %v", err)
+	}
+	s.Cache = NewMemoryCache(10)
+	ctx := context.Background()
+
+	// Places are cached
+	for i := 0; i < 3; i++ {
+		res, err := s.Places(ctx, PlacesRequest{Query: "avenue"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if res.Cached != (i != 0) {
+			t.Errorf("request #%d: expected Cached to be %v", i, i != 0)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("expected a single request, got %d", requests)
+	}
+
+	// Departures aren't by default
+	scope := s.Scope("sandbox")
+	for i := 0; i < 2; i++ {
+		_, err := scope.DeparturesSA(ctx, ConnectionsRequest{}, "stop_area:A")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if requests != 3 {
+		t.Errorf("expected departures not to be cached, got %d requests", requests)
+	}
+
+	// With a TTL they are, except realtime ones
+	s.CacheTTLs = map[string]time.Duration{departuresEndpoint: time.Minute}
+	for i := 0; i < 2; i++ {
+		_, err := scope.DeparturesSA(ctx, ConnectionsRequest{Freshness: types.DataFreshnessRealTime}, "stop_area:A")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if requests != 5 {
+		t.Errorf("expected realtime departures not to be cached, got %d requests", requests)
+	}
+}
+
+func Test_Session_Cache_shared(t *testing.T) {
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		fmt.Fprint(w, `{"places": []}`)
+	}))
+	defer ts.Close()
+
+	// Two sessions with different API keys share a cache
+	cache := NewMemoryCache(10)
+	newSession := func(key string, opts ...Option) *Session {
+		s, err := NewSession(key, append(opts, WithBaseURL(ts.URL), WithCache(cache, nil))...)
+		if err != nil {
+			t.Fatalf("error while creating session: %v", err)
+		}
+		return s
+	}
+	language := func(lang string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context, call *Call) error {
+				call.Header.Set("Accept-Language", lang)
+				return next(ctx, call)
+			}
+		}
+	}
+	sessions := []*Session{
+		newSession("a"),
+		newSession("b"),
+		newSession("a", WithMiddlewares(language("fr"))),
+		newSession("a", WithMiddlewares(language("en"))),
+	}
+
+	// None of them is served the response of another
+	ctx := context.Background()
+	for i, s := range sessions {
+		res, err := s.Places(ctx, PlacesRequest{Query: "avenue"})
+		if err != nil {
+			t.Fatalf("session #%d: unexpected error: %v", i, err)
+		}
+		if res.Cached {
+			t.Errorf("session #%d: unexpected response from the cache", i)
+		}
+	}
+
+	// But a session with the same API key & headers is
+	res, err := newSession("a", WithMiddlewares(language("fr"))).Places(ctx, PlacesRequest{Query: "avenue"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !res.Cached || requests != int32(len(sessions)) {
+		t.Errorf("expected a response from the cache, got %d requests", requests)
+	}
+}
@@ -0,0 +1,123 @@
+package navitia
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// A flight is an in-flight request, whose response is shared by identical concurrent calls
+type flight struct {
+	// done is closed once the flight has landed
+	done chan struct{}
+
+	// The shared response
+	body       []byte
+	statusCode int
+	size       int64
+	err        error
+
+	// own is set if err is the leader's own: caused by its context, or by the Limiter given its deadline
+	own bool
+}
+
+// A flightGroup holds the in-flight requests of a Session, by request key
+//
+// Its zero value is ready to use.
+type flightGroup struct {
+	mu      sync.Mutex
+	flights map[string]*flight
+}
+
+// join returns the in-flight request for the key, creating it if there is none.
+// If it was created, the caller is its leader: it must execute the request then call land.
+func (g *flightGroup) join(key string) (f *flight, leader bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if f, ok := g.flights[key]; ok {
+		return f, false
+	}
+	if g.flights == nil {
+		g.flights = make(map[string]*flight)
+	}
+	f = &flight{done: make(chan struct{})}
+	g.flights[key] = f
+	return f, true
+}
+
+// land removes the flight from the group, and releases the calls waiting for it
+func (g *flightGroup) land(key string, f *flight) {
+	g.mu.Lock()
+	delete(g.flights, key)
+	g.mu.Unlock()
+	close(f.done)
+}
+
+// fetchShared requests a url like fetch, sharing the body of the response with the identical concurrent calls, and decodes it in res.
+//
+// Only calls with the same URL & headers are identical, and each one decodes its own copy of the results.
+// If the leading call fails because of its own context, such as when it is cancelled or its deadline is too short, the calls waiting for it which aren't try again.
+func (s *Session) fetchShared(ctx context.Context, url string, call *Call, res results, ttl time.Duration) error {
+	key := s.requestKey(url, call.Header)
+	for {
+		f, leader := s.flights.join(key)
+		if leader {
+			return s.lead(ctx, url, key, call, res, ttl, f)
+		}
+
+		// Wait for the leader
+		select {
+		case <-f.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if f.own && ctx.Err() == nil {
+			continue
+		}
+
+		// Use the shared response
+		res.sharing()
+		call.StatusCode = f.statusCode
+		call.ResponseSize = f.size
+		if f.err != nil {
+			return f.err
+		}
+		err := json.Unmarshal(f.body, res)
+		if err != nil {
+			return errors.Wrap(err, "JSON decoding of shared response failed")
+		}
+		res.parsing()
+		return nil
+	}
+}
+
+// lead executes the request of a flight, shares its response, and decodes it in res
+func (s *Session) lead(ctx context.Context, url string, key string, call *Call, res results, ttl time.Duration, f *flight) error {
+	defer s.flights.land(key, f)
+
+	// Send the request & read the whole body
+	resp, reader, err := s.send(ctx, url, call, res)
+	if err == nil {
+		defer resp.Body.Close()
+		f.body, err = readAll(reader, call)
+	}
+	f.statusCode, f.size, f.err = call.StatusCode, call.ResponseSize, err
+	if err != nil {
+		f.own = ctx.Err() != nil || call.deadlined || errors.Is(err, ErrLimiterDeadline)
+		return err
+	}
+
+	// Decode it
+	err = json.Unmarshal(f.body, res)
+	if err != nil {
+		return errors.Wrap(err, "JSON decoding failed")
+	}
+	if ttl = responseTTL(ttl, resp.Header, time.Now()); ttl != 0 {
+		s.Cache.Set(key, f.body, ttl)
+	}
+	res.parsing()
+	return nil
+}
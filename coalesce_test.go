@@ -0,0 +1,235 @@
+package navitia
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Test_Session_Coalesce checks that identical concurrent calls share a single request, each one receiving its own copy of the results
+func Test_Session_Coalesce(t *testing.T) {
+	var requests int32
+	received := make(chan struct{}, 1)
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		received <- struct{}{}
+		<-release
+		fmt.Fprint(w, `{"places": [{"id": "stop_area:RAT:SA:GDLYO", "embedded_type": "stop_area", "stop_area": {"id": "stop_area:RAT:SA:GDLYO"}}]}`)
+	}))
+	defer ts.Close()
+
+	session, err := NewSession("", WithBaseURL(ts.URL), WithCoalescing())
+	if err != nil {
+		t.Fatalf("error while creating session: %v", err)
+	}
+
+	// Launch the calls
+	const calls = 10
+	var (
+		wg      sync.WaitGroup
+		results [calls]*PlacesResults
+		errs    [calls]error
+	)
+	for i := 0; i < calls; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = session.Places(context.Background(), PlacesRequest{Query: "gare de lyon"})
+		}(i)
+	}
+
+	// Let them join the request before answering it
+	<-received
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&requests); got != 1 {
+		t.Errorf("expected a single request, got %d", got)
+	}
+	var shared int
+	for i, res := range results {
+		if errs[i] != nil {
+			t.Fatalf("call #%d: unexpected error: %v", i, errs[i])
+		}
+		if len(res.Places) != 1 {
+			t.Fatalf("call #%d: expected 1 place, got %d", i, len(res.Places))
+		}
+		if res.Shared {
+			shared++
+		}
+		for j := 0; j < i; j++ {
+			if &results[j].Places[0] == &res.Places[0] {
+				t.Errorf("calls #%d & #%d share their results", j, i)
+			}
+		}
+	}
+	if shared != calls-1 {
+		t.Errorf("expected %d shared results, got %d", calls-1, shared)
+	}
+}
+
+// Test_Session_Coalesce_cancelled checks that a call waiting for a cancelled one tries again
+func Test_Session_Coalesce_cancelled(t *testing.T) {
+	var requests int32
+	received := make(chan struct{}, 2)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- struct{}{}
+		if atomic.AddInt32(&requests, 1) == 1 {
+			<-r.Context().Done()
+			return
+		}
+		fmt.Fprint(w, `{"places": []}`)
+	}))
+	defer ts.Close()
+
+	session, err := NewSession("", WithBaseURL(ts.URL), WithCoalescing())
+	if err != nil {
+		t.Fatalf("error while creating session: %v", err)
+	}
+
+	// Launch the leader, which will be cancelled
+	ctx, cancel := context.WithCancel(context.Background())
+	leaderErr := make(chan error)
+	go func() {
+		_, err := session.Places(ctx, PlacesRequest{Query: "gare de lyon"})
+		leaderErr <- err
+	}()
+	<-received
+
+	// Launch the follower
+	followerErr := make(chan error)
+	go func() {
+		_, err := session.Places(context.Background(), PlacesRequest{Query: "gare de lyon"})
+		followerErr <- err
+	}()
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+
+	if err := <-leaderErr; err == nil {
+		t.Errorf("expected the cancelled call to fail")
+	}
+	if err := <-followerErr; err != nil {
+		t.Errorf("expected the waiting call to succeed, got %v", err)
+	}
+	if got := atomic.LoadInt32(&requests); got != 2 {
+		t.Errorf("expected 2 requests, got %d", got)
+	}
+}
+
+// Test_Session_Coalesce_headers checks that concurrent calls with different headers don't share a request
+func Test_Session_Coalesce_headers(t *testing.T) {
+	var requests int32
+	received := make(chan struct{}, 2)
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		received <- struct{}{}
+		<-release
+		fmt.Fprintf(w, `{"places": [{"id": %q}]}`, r.Header.Get("Accept-Language"))
+	}))
+	defer ts.Close()
+
+	// Sets the language found in the context
+	language := func(next Handler) Handler {
+		return func(ctx context.Context, call *Call) error {
+			call.Header.Set("Accept-Language", ctx.Value(languageKey{}).(string))
+			return next(ctx, call)
+		}
+	}
+	session, err := NewSession("", WithBaseURL(ts.URL), WithCoalescing(), WithMiddlewares(language))
+	if err != nil {
+		t.Fatalf("error while creating session: %v", err)
+	}
+
+	// Launch the calls, both being in flight at the same time
+	languages := []string{"fr", "en"}
+	var (
+		wg      sync.WaitGroup
+		results [2]*PlacesResults
+		errs    [2]error
+	)
+	for i, lang := range languages {
+		wg.Add(1)
+		go func(i int, ctx context.Context) {
+			defer wg.Done()
+			results[i], errs[i] = session.Places(ctx, PlacesRequest{Query: "gare de lyon"})
+		}(i, context.WithValue(context.Background(), languageKey{}, lang))
+	}
+	<-received
+	<-received
+	close(release)
+	wg.Wait()
+
+	for i, lang := range languages {
+		if errs[i] != nil {
+			t.Fatalf("call #%d: unexpected error: %v", i, errs[i])
+		}
+		if len(results[i].Places) != 1 || string(results[i].Places[0].ID) != lang || results[i].Shared {
+			t.Errorf("call #%d: expected its own response, got %+v", i, results[i])
+		}
+	}
+	if got := atomic.LoadInt32(&requests); got != 2 {
+		t.Errorf("expected 2 requests, got %d", got)
+	}
+}
+
+// languageKey is the context key of the language used by Test_Session_Coalesce_headers
+type languageKey struct{}
+
+// Test_Session_Coalesce_deadline checks that a call waiting for one whose retry was cut short by its deadline tries again
+func Test_Session_Coalesce_deadline(t *testing.T) {
+	var requests int32
+	received := make(chan struct{}, 2)
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- struct{}{}
+		if atomic.AddInt32(&requests, 1) == 1 {
+			<-release
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"places": []}`)
+	}))
+	defer ts.Close()
+
+	session, err := NewSession("", WithBaseURL(ts.URL), WithCoalescing(), WithRetry(RetryPolicy{MaxAttempts: 2, BaseDelay: time.Hour}))
+	if err != nil {
+		t.Fatalf("error while creating session: %v", err)
+	}
+
+	// Launch the leader, whose deadline is too short to retry
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	leaderErr := make(chan error)
+	go func() {
+		_, err := session.Places(ctx, PlacesRequest{Query: "gare de lyon"})
+		leaderErr <- err
+	}()
+	<-received
+
+	// Launch the follower
+	followerErr := make(chan error)
+	go func() {
+		_, err := session.Places(context.Background(), PlacesRequest{Query: "gare de lyon"})
+		followerErr <- err
+	}()
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+
+	if err := <-leaderErr; err == nil {
+		t.Errorf("expected the leading call to fail")
+	}
+	if err := <-followerErr; err != nil {
+		t.Errorf("expected the waiting call to succeed, got %v", err)
+	}
+	if got := atomic.LoadInt32(&requests); got != 2 {
+		t.Errorf("expected 2 requests, got %d", got)
+	}
+}
@@ -0,0 +1,59 @@
+package navitia
+
+import (
+	"github.com/aabizri/navitia/types"
+	"github.com/pkg/errors"
+)
+
+// collections maps the navitia-side type names found in IDs (see types.ID.Type) to the name of their collection in the API
+var collections = map[string]string{
+	"network":         "networks",
+	"line":            "lines",
+	"route":           "routes",
+	"stop_area":       "stop_areas",
+	"commercial_mode": "commercial_modes",
+	"physical_mode":   "physical_modes",
+	"company":         "companies",
+	"stop_point":      "stop_points",
+	"vehicle_journey": "vehicle_journeys",
+}
+
+// objectPath returns the path of a public transport object, such as /stop_areas/{id}
+//
+// The collection of the object is inferred from its ID, if it is not one of the allowed types, objectPath returns an error.
+// If no allowed type is given, every known type is allowed.
+func objectPath(id types.ID, allowed ...string) (string, error) {
+	// Get the type of the object
+	typ := id.Type()
+	collection, ok := collections[typ]
+	if !ok {
+		return "", errors.Errorf("can't infer the collection of the object from its ID (%s)", id)
+	}
+
+	// Check that it is allowed
+	if len(allowed) != 0 {
+		var found bool
+		for _, a := range allowed {
+			if a == typ {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return "", errors.Errorf("objects of type %s (%s) aren't allowed here, only %v are", typ, id, allowed)
+		}
+	}
+
+	return "/" + collection + "/" + string(id), nil
+}
+
+// objectURL returns the URL of a public transport object in a scope, such as /coverage/{region}/stop_areas/{id}
+//
+// See objectPath for the allowed types.
+func (scope *Scope) objectURL(id types.ID, allowed ...string) (string, error) {
+	path, err := objectPath(id, allowed...)
+	if err != nil {
+		return "", err
+	}
+	return scope.session.APIURL + "/coverage/" + string(scope.region) + path, nil
+}
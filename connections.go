@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 	"unsafe"
 
@@ -14,19 +15,32 @@ import (
 
 // A Connection is either a Departure or an Arrival
 type Connection struct {
-	Display   types.Display
-	StopPoint types.StopPoint
-	Route     types.Route
-	//StopDateTime
+	Display   types.Display   `json:"display_informations"`
+	StopPoint types.StopPoint `json:"stop_point"`
+	Route     types.Route     `json:"route"`
+
+	// The times of the vehicle at the stop point
+	StopDateTime types.StopDateTime `json:"stop_date_time"`
 }
 
 // ConnectionsResults holds the results of a departures or arrivals request.
 type ConnectionsResults struct {
 	Connections []Connection
 
+	// Disruptions lists every disruption referenced in the connections, see types.StopDateTime.Disruptions
+	Disruptions []types.Disruption
+
 	Paging Paging `json:"links"`
 
+	// Pagination describes the position of this page in the results
+	Pagination Pagination `json:"pagination"`
+
+	// Context holds the timezone of the region, in which the date times of the results are expressed
+	Context types.Context `json:"context"`
+
 	Logging `json:"-"`
+
+	session *Session
 }
 
 // UnmarshalJSON implements unmarshalling for ConnectionsResults.
@@ -35,13 +49,19 @@ func (cr *ConnectionsResults) UnmarshalJSON(b []byte) error {
 	// We define some of the value as pointers to the real values, allowing us to bypass copying in cases where we don't need to process the data
 	data := &struct {
 		// Pointers to the corresponding real values
-		Paging *Paging `json:"links"`
+		Paging      *Paging             `json:"links"`
+		Pagination  *Pagination         `json:"pagination"`
+		Disruptions *[]types.Disruption `json:"disruptions"`
+		Context     *types.Context      `json:"context"`
 
 		// Value to process
 		Departures *[]Connection `json:"departures"`
 		Arrivals   *[]Connection `json:"arrivals"`
 	}{
-		Paging: &cr.Paging,
+		Paging:      &cr.Paging,
+		Pagination:  &cr.Pagination,
+		Disruptions: &cr.Disruptions,
+		Context:     &cr.Context,
 	}
 
 	// Now unmarshall the raw data into the analogous structure
@@ -85,13 +105,25 @@ type ConnectionsRequest struct {
 
 	// Enables GeoJSON data in the reply. GeoJSON objects can be VERY large ! >1MB.
 	Geo bool
+
+	// local is set once the date times are expressed in the region's timezone, see localQuery
+	local bool
+}
+
+// in returns the request with its date times expressed in the given location, see localQuery
+func (req ConnectionsRequest) in(loc *time.Location) query {
+	if loc != nil {
+		req.From = req.From.In(loc)
+		req.local = true
+	}
+	return req
 }
 
 func (req ConnectionsRequest) toURL() (url.Values, error) {
 	values := url.Values{}
 
 	if datetime := req.From; !datetime.IsZero() {
-		str := datetime.Format(types.DateTimeFormat)
+		str := formatRequestDateTime(datetime, req.local)
 		values.Add("datetime", str)
 	}
 
@@ -122,7 +154,7 @@ func (req ConnectionsRequest) toURL() (url.Values, error) {
 
 // departures is the internal function used by Departures & Arrivals functions
 func (s *Session) connections(ctx context.Context, url string, req ConnectionsRequest) (*ConnectionsResults, error) {
-	var results = &ConnectionsResults{}
+	var results = &ConnectionsResults{session: s}
 	err := s.request(ctx, url, req, results)
 	return results, err
 }
@@ -181,3 +213,22 @@ func (s *Session) ArrivalsC(ctx context.Context, req ConnectionsRequest, coords
 
 	return s.connections(ctx, url, req)
 }
+
+// ResumeConnections requests the page of departures or arrivals a cursor points to, such as ConnectionsResults.Paging.Next.
+//
+// The cursor must point to the departures or arrivals endpoint of the session's API, in the scope's region if it is in one, otherwise ErrInvalidCursor is returned.
+func (scope *Scope) ResumeConnections(ctx context.Context, cursor Cursor) (*ConnectionsResults, error) {
+	url, err := scope.session.cursorURL(cursor, departuresEndpoint, arrivalsEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the region
+	if prefix := "/coverage/"; strings.Contains(url, prefix) && !strings.Contains(url, prefix+string(scope.region)+"/") {
+		return nil, errors.Wrapf(ErrInvalidCursor, "cursor doesn't point to the %s region", scope.region)
+	}
+
+	var results = &ConnectionsResults{session: scope.session}
+	err = scope.session.requestURL(ctx, url, results)
+	return results, err
+}
@@ -71,3 +71,22 @@ func TestConnectionsSA(t *testing.T) {
 func Test_ConnectionsResults_Unmarshal(t *testing.T) {
 	testUnmarshal(t, testData["connections"], reflect.TypeOf(ConnectionsResults{}))
 }
+
+// Test_ConnectionsResults_Unmarshal_StopDateTime checks that every connection of the correct testdata has its stop point & stop date time decoded
+func Test_ConnectionsResults_Unmarshal_StopDateTime(t *testing.T) {
+	for name, datum := range testData["connections"].correct {
+		var cr = &ConnectionsResults{}
+		err := cr.UnmarshalJSON(datum)
+		if err != nil {
+			t.Fatalf("%s: error while unmarshalling: %v", name, err)
+		}
+		for i, c := range cr.Connections {
+			if c.StopPoint.ID == "" {
+				t.Errorf("%s: connection #%d has no stop point", name, i)
+			}
+			if c.StopDateTime.Departure.IsZero() && c.StopDateTime.Arrival.IsZero() {
+				t.Errorf("%s: connection #%d has no date time", name, i)
+			}
+		}
+	}
+}
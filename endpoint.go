@@ -0,0 +1,71 @@
+package navitia
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/aabizri/navitia/types"
+)
+
+// knownEndpoints lists the endpoints of the API, as found in the request paths
+var knownEndpoints = map[string]bool{
+	regionEndpoint:            true,
+	coordsEndpoint:            true,
+	placesEndpoint:            true,
+	placesNearbyEndpoint:      true,
+	ptObjectsEndpoint:         true,
+	journeysEndpoint:          true,
+	isochronesEndpoint:        true,
+	departuresEndpoint:        true,
+	arrivalsEndpoint:          true,
+	stopSchedulesEndpoint:     true,
+	routeSchedulesEndpoint:    true,
+	terminusSchedulesEndpoint: true,
+	trafficReportsEndpoint:    true,
+	lineReportsEndpoint:       true,
+	linesEndpoint:             true,
+	routesEndpoint:            true,
+	stopAreasEndpoint:         true,
+	stopPointsEndpoint:        true,
+	networksEndpoint:          true,
+	companiesEndpoint:         true,
+	physicalModesEndpoint:     true,
+	commercialModesEndpoint:   true,
+	vehicleJourneysEndpoint:   true,
+}
+
+// endpointOf returns the endpoint of a request URL: the last known endpoint in its path.
+//
+// For example, the endpoint of /coverage/fr-idf/stop_areas/{id}/departures is "departures", while the one of /coverage/fr-idf/lines/{id} is "lines".
+// If no endpoint is known, it returns an empty string.
+func endpointOf(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	segments := strings.Split(u.Path, "/")
+	for i := len(segments) - 1; i >= 0; i-- {
+		if knownEndpoints[segments[i]] {
+			return segments[i]
+		}
+	}
+	return ""
+}
+
+// regionOf returns the region of a request URL: the segment following "coverage" in its path.
+//
+// For example, the region of /coverage/fr-idf/journeys is "fr-idf".
+// If the request isn't scoped to a region, it returns an empty string.
+func regionOf(rawURL string) types.ID {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	segments := strings.Split(u.Path, "/")
+	for i := 0; i < len(segments)-1; i++ {
+		if segments[i] == regionEndpoint {
+			return types.ID(segments[i+1])
+		}
+	}
+	return ""
+}
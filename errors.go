@@ -3,7 +3,10 @@ package navitia
 import (
 	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
+	"strings"
 
 	"github.com/pkg/errors"
 )
@@ -16,15 +19,15 @@ const (
 	// 404 Errors
 
 	RemoteErrDateOutOfBounds       RemoteErrorID = "date_out_of_bounds"         // When the given date is out of bounds of the production dates of the region
-	RemoteErrNoOrigin                            = "no_origin"                  // Couldn’t find an origin for the journeys
-	RemoteErrNoDestination                       = "no_destination"             // Couldn’t find an destination for the journeys
-	RemoteErrNoOriginNoDestination               = "nor_origin_nor_destination" // Couldn’t find an origin nor a destination for the journeys
-	RemoteErrUnknownObject                       = "unknown_object"             // Unknown Object
+	RemoteErrNoOrigin              RemoteErrorID = "no_origin"                  // Couldn’t find an origin for the journeys
+	RemoteErrNoDestination         RemoteErrorID = "no_destination"             // Couldn’t find an destination for the journeys
+	RemoteErrNoOriginNoDestination RemoteErrorID = "nor_origin_nor_destination" // Couldn’t find an origin nor a destination for the journeys
+	RemoteErrUnknownObject         RemoteErrorID = "unknown_object"             // Unknown Object
 
 	// 400 Errors
 
-	RemoteErrBadFilter     = "bad_filter"      // Bad filter (with custom filter)
-	RemoteErrUnableToParse = "unable_to_parse" // Unable to parse mal-formed custom filter"
+	RemoteErrBadFilter     RemoteErrorID = "bad_filter"      // Bad filter (with custom filter)
+	RemoteErrUnableToParse RemoteErrorID = "unable_to_parse" // Unable to parse mal-formed custom filter"
 )
 
 // remoteErrorsDescriptions contains human-readable descriptions for a given remote error ID
@@ -40,11 +43,49 @@ var remoteErrorsDescriptions = map[RemoteErrorID]string{
 	RemoteErrUnableToParse:         "Unable to parse mal-formed custom filter",
 }
 
+// ErrXXX are sentinel errors matching RemoteErrors, to be used with errors.Is:
+//
+//	if errors.Is(err, navitia.ErrNoOrigin) { ... }
+var (
+	// Matching remote error IDs
+	ErrDateOutOfBounds = errors.New("navitia: date out of bounds of the production dates of the region")
+	ErrNoOrigin        = errors.New("navitia: no origin found for the journeys")
+	ErrNoDestination   = errors.New("navitia: no destination found for the journeys")
+	ErrUnknownObject   = errors.New("navitia: unknown object")
+	ErrBadFilter       = errors.New("navitia: bad filter")
+
+	// Matching HTTP status codes
+	ErrUnauthorized = errors.New("navitia: unauthorized, the API key is missing, invalid or doesn't give access to this resource") // 401 & 403
+	ErrRateLimited  = errors.New("navitia: rate limited, too many requests")                                                       // 429
+	ErrServer       = errors.New("navitia: remote server failure")                                                                 // 5xx
+)
+
+// remoteErrorsSentinels maps the remote error IDs to the sentinel errors they match
+var remoteErrorsSentinels = map[RemoteErrorID][]error{
+	RemoteErrDateOutOfBounds:       {ErrDateOutOfBounds},
+	RemoteErrNoOrigin:              {ErrNoOrigin},
+	RemoteErrNoDestination:         {ErrNoDestination},
+	RemoteErrNoOriginNoDestination: {ErrNoOrigin, ErrNoDestination},
+	RemoteErrUnknownObject:         {ErrUnknownObject},
+	RemoteErrBadFilter:             {ErrBadFilter},
+	RemoteErrUnableToParse:         {ErrBadFilter},
+}
+
+// maxErrorBodySize is the maximum size of an error body we keep, in bytes
+const maxErrorBodySize = 64 * 1000
+
 // A RemoteError represents an error sent by the server
+//
+// It matches the ErrXXX sentinel errors through errors.Is.
 type RemoteError struct {
 	StatusCode int
 	ID         RemoteErrorID `json:"id"`
 	Message    string        `json:"message"`
+
+	// The raw body & header of the response, preserved for diagnostics
+	// The body is truncated to 64kB
+	Body   []byte      `json:"-"`
+	Header http.Header `json:"-"`
 }
 
 // Error formats the error in a human-readable format
@@ -61,21 +102,69 @@ func (err RemoteError) Error() string {
 		}
 		s += err.Message
 	} else {
-		s = fmt.Sprintf("remote failure (id: %s): %s", err.ID, err.Message)
+		s = fmt.Sprintf("remote failure (status: %d, id: %s): %s", err.StatusCode, err.ID, err.Message)
 	}
 
 	return s
 }
 
+// Is reports whether the RemoteError matches the target sentinel error, allowing the use of errors.Is
+func (err RemoteError) Is(target error) bool {
+	// Check by ID
+	for _, sentinel := range remoteErrorsSentinels[err.ID] {
+		if sentinel == target {
+			return true
+		}
+	}
+
+	// Then by status code
+	switch code := err.StatusCode; {
+	case code == http.StatusUnauthorized || code == http.StatusForbidden:
+		return target == ErrUnauthorized
+	case code == http.StatusTooManyRequests:
+		return target == ErrRateLimited
+	case code >= 500:
+		return target == ErrServer
+	}
+	return false
+}
+
 // parseRemoteError parses a non 200 OK status-coded response and returns the error
+//
+// navitia sends errors either as {"error": {"id": ..., "message": ...}} or as {"id": ..., "message": ...},
+// but proxies in front of it may send anything, such as HTML: in that case the message is the status text.
+// It doesn't close the body.
 func parseRemoteError(resp *http.Response) error {
-	var remoteErr = &RemoteError{StatusCode: resp.StatusCode}
+	var remoteErr = &RemoteError{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+	}
 
-	// Parse it
-	dec := json.NewDecoder(resp.Body)
-	err := dec.Decode(&remoteErr)
+	// Read the body, then drain the rest of it so that the connection can be reused
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
 	if err != nil {
-		return errors.Wrap(err, "parseRemoteError: error while decoding JSON")
+		return errors.Wrapf(err, "parseRemoteError: error while reading body of %d response", resp.StatusCode)
+	}
+	io.Copy(ioutil.Discard, resp.Body)
+	remoteErr.Body = body
+
+	// Parse it, both the wrapped & flat forms
+	data := &struct {
+		Error *RemoteError `json:"error"`
+		*RemoteError
+	}{
+		Error:       remoteErr,
+		RemoteError: remoteErr,
+	}
+	err = json.Unmarshal(body, data)
+
+	// If that isn't JSON or there is no message, use the status text
+	if err != nil || remoteErr.Message == "" {
+		msg := strings.TrimSpace(http.StatusText(resp.StatusCode))
+		if msg == "" {
+			msg = resp.Status
+		}
+		remoteErr.Message = msg
 	}
 
 	// Return
@@ -0,0 +1,102 @@
+package navitia
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+// Test_parseRemoteError checks the parsing of error responses, and their matching against the sentinel errors
+func Test_parseRemoteError(t *testing.T) {
+	// Declare this test to be run in parallel
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		id         RemoteErrorID
+		matches    []error
+		notMatches []error
+	}{
+		{
+			name:       "wrapped",
+			statusCode: 404,
+			body:       `{"error": {"id": "no_origin", "message": "no origin point"}}`,
+			id:         RemoteErrNoOrigin,
+			matches:    []error{ErrNoOrigin},
+			notMatches: []error{ErrNoDestination, ErrServer},
+		},
+		{
+			name:       "flat",
+			statusCode: 404,
+			body:       `{"id": "nor_origin_nor_destination", "message": "no origin nor destination"}`,
+			id:         RemoteErrNoOriginNoDestination,
+			matches:    []error{ErrNoOrigin, ErrNoDestination},
+		},
+		{
+			name:       "date out of bounds",
+			statusCode: 404,
+			body:       `{"error": {"id": "date_out_of_bounds", "message": "date is not in data production period"}}`,
+			id:         RemoteErrDateOutOfBounds,
+			matches:    []error{ErrDateOutOfBounds},
+		},
+		{
+			name:       "unauthorized",
+			statusCode: 401,
+			body:       `{"message": "You are not authorized to access this resource"}`,
+			matches:    []error{ErrUnauthorized},
+			notMatches: []error{ErrRateLimited},
+		},
+		{
+			name:       "rate limited",
+			statusCode: 429,
+			body:       `{"message": "Too many requests"}`,
+			matches:    []error{ErrRateLimited},
+		},
+		{
+			name:       "html proxy error",
+			statusCode: 502,
+			body:       `<html><body><h1>502 Bad Gateway</h1></body></html>`,
+			matches:    []error{ErrServer},
+			notMatches: []error{ErrUnauthorized},
+		},
+	}
+
+	for _, test := range tests {
+		resp := &http.Response{
+			StatusCode: test.statusCode,
+			Header:     http.Header{"X-Test": []string{test.name}},
+			Body:       ioutil.NopCloser(bytes.NewBufferString(test.body)),
+		}
+
+		err := errors.Wrap(parseRemoteError(resp), "wrapped")
+
+		var remoteErr *RemoteError
+		if !errors.As(err, &remoteErr) {
+			t.Fatalf("%s: expected a *RemoteError, got %#v", test.name, err)
+		}
+		if remoteErr.ID != test.id {
+			t.Errorf("%s: expected ID %q, got %q", test.name, test.id, remoteErr.ID)
+		}
+		if remoteErr.Message == "" {
+			t.Errorf("%s: expected a message, got none", test.name)
+		}
+		if string(remoteErr.Body) != test.body || remoteErr.Header.Get("X-Test") != test.name {
+			t.Errorf("%s: the raw response wasn't preserved: %#v", test.name, remoteErr)
+		}
+		for _, target := range test.matches {
+			if !errors.Is(err, target) {
+				t.Errorf("%s: expected error to match %v", test.name, target)
+			}
+		}
+		for _, target := range test.notMatches {
+			if errors.Is(err, target) {
+				t.Errorf("%s: expected error not to match %v", test.name, target)
+			}
+		}
+	}
+}
@@ -0,0 +1,111 @@
+/*
+Package filter implements a builder for the filters used by the navitia public transport referential (the "filter" parameter).
+
+Filters are built from the predefined objects and their attributes, then combined:
+
+	f := filter.Line.Code.Eq("6").And(filter.Network.ID.Eq("network:RAT"))
+
+Every value is escaped, and the filter is validated locally, so that a malformed filter is reported as a Go error instead of a remote "bad_filter" error.
+
+See http://doc.navitia.io/#filter
+*/
+package filter
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// A Filter is a filter expression, to be given to requests supporting filters.
+//
+// The zero value is an empty filter, which filters nothing.
+// Filters are immutable, combining them returns a new Filter.
+type Filter struct {
+	// expr is the rendered expression
+	expr string
+
+	// compound is true when the expression combines other expressions, and as such needs parentheses when combined again
+	compound bool
+
+	// err is the first error encountered while building the filter
+	err error
+}
+
+// Empty reports whether the filter is empty
+func (f Filter) Empty() bool {
+	return f.expr == "" && f.err == nil
+}
+
+// Validate checks the filter, returning the first error encountered while building it
+func (f Filter) Validate() error {
+	return f.err
+}
+
+// String renders the filter in navitia's syntax
+func (f Filter) String() string {
+	return f.expr
+}
+
+// And returns a filter matching objects matched by both f and other
+func (f Filter) And(other Filter) Filter {
+	return combine("and", f, other)
+}
+
+// Or returns a filter matching objects matched by either f or other
+func (f Filter) Or(other Filter) Filter {
+	return combine("or", f, other)
+}
+
+// Get returns a filter matching the objects of type o related to the objects matched by f.
+//
+// For example, Get(StopArea, Line.Code.Eq("6")) matches the stop areas of the lines with code 6.
+func Get(o Object, f Filter) Filter {
+	if err := o.check(); err != nil {
+		return Filter{err: err}
+	}
+	if f.err != nil {
+		return f
+	}
+	if f.expr == "" {
+		return Filter{err: errors.Errorf("filter: can't GET %s from an empty filter", o.name)}
+	}
+	return Filter{expr: "get " + o.name + " <- " + f.operand(), compound: true}
+}
+
+// combine combines two filters with the given keyword
+func combine(keyword string, a Filter, b Filter) Filter {
+	switch {
+	case a.err != nil:
+		return a
+	case b.err != nil:
+		return b
+	case a.expr == "" || b.expr == "":
+		return Filter{err: errors.Errorf("filter: can't combine (%s) an empty filter", keyword)}
+	}
+	return Filter{expr: a.operand() + " " + keyword + " " + b.operand(), compound: true}
+}
+
+// operand returns the expression, parenthesized if needed
+func (f Filter) operand() string {
+	if f.compound {
+		return "(" + f.expr + ")"
+	}
+	return f.expr
+}
+
+// identifierRegexp matches valid object, attribute & method names
+var identifierRegexp = regexp.MustCompile(`^[a-z][a-z_]*$`)
+
+// bareValueRegexp matches values which can be sent without quoting them
+var bareValueRegexp = regexp.MustCompile(`^[a-zA-Z0-9_:.\-]+$`)
+
+// escape renders a value, quoting it if needed
+func escape(value string) string {
+	if bareValueRegexp.MatchString(value) {
+		return value
+	}
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	return `"` + r.Replace(value) + `"`
+}
@@ -0,0 +1,59 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/aabizri/navitia/types"
+)
+
+// TestFilter_String tests the rendering of known filters
+func TestFilter_String(t *testing.T) {
+	known := []struct {
+		filter   Filter
+		expected string
+	}{
+		{Line.Code.Eq("6"), `line.code=6`},
+		{Line.ID.Eq("line:RAT:M6"), `line.id=line:RAT:M6`},
+		{StopArea.Name.Eq(`Gare "de" Lyon\`), `stop_area.name="Gare \"de\" Lyon\\"`},
+		{Line.Code.Eq("6").And(Network.ID.Eq("network:RAT")), `line.code=6 and network.id=network:RAT`},
+		{Line.Code.Eq("6").Or(Line.Code.Eq("7")).And(Network.ID.Ne("network:RAT")), `(line.code=6 or line.code=7) and network.id<>network:RAT`},
+		{StopPoint.DWithin(types.Coordinates{Longitude: 2.37731, Latitude: 48.847002}, 200), `stop_point.coord DWITHIN(2.37731, 48.847002, 200)`},
+		{Get(StopArea, Line.Code.Eq("6")), `get stop_area <- line.code=6`},
+		{Get(StopArea, Line.Code.Eq("6").Or(Line.Code.Eq("7"))), `get stop_area <- (line.code=6 or line.code=7)`},
+		{Line.HasCode("source", "100110004:4"), `line.has_code(source, 100110004:4)`},
+		{VehicleJourney.HasDisruption(), `vehicle_journey.has_disruption()`},
+		{NewObject("line_group").Attr("uri").Eq("lg"), `line_group.uri=lg`},
+	}
+
+	for i, k := range known {
+		if err := k.filter.Validate(); err != nil {
+			t.Errorf("case #%d: unexpected error: %v", i, err)
+		} else if got := k.filter.String(); got != k.expected {
+			t.Errorf("case #%d: expected %s, got %s", i, k.expected, got)
+		}
+	}
+}
+
+// TestFilter_Validate tests that malformed filters are reported
+func TestFilter_Validate(t *testing.T) {
+	invalid := []Filter{
+		Line.Code.Eq(""),
+		Line.Attr("Code; DROP").Eq("6"),
+		NewObject("").ID.Eq("6"),
+		StopPoint.DWithin(types.Coordinates{}, 0),
+		Line.HasCode("source", ""),
+		Line.Code.Eq("6").And(Filter{}),
+		Line.Code.Eq("").Or(Line.Code.Eq("7")),
+		Get(StopArea, Filter{}),
+	}
+
+	for i, f := range invalid {
+		if err := f.Validate(); err == nil {
+			t.Errorf("case #%d: expected an error, got none (filter: %s)", i, f)
+		}
+	}
+
+	if !(Filter{}).Empty() {
+		t.Errorf("expected the zero filter to be empty")
+	}
+}
@@ -0,0 +1,155 @@
+package filter
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/aabizri/navitia/types"
+	"github.com/pkg/errors"
+)
+
+// An Object is a type of public transport object on which filters can be expressed.
+type Object struct {
+	name string
+
+	// The attributes shared by every object
+	ID   Attribute
+	Name Attribute
+	Code Attribute
+}
+
+// These are the objects known by navitia
+var (
+	Network        = NewObject("network")
+	Line           = NewObject("line")
+	Route          = NewObject("route")
+	StopArea       = NewObject("stop_area")
+	StopPoint      = NewObject("stop_point")
+	CommercialMode = NewObject("commercial_mode")
+	PhysicalMode   = NewObject("physical_mode")
+	Company        = NewObject("company")
+	VehicleJourney = NewObject("vehicle_journey")
+	Disruption     = NewObject("disruption")
+)
+
+// NewObject returns an Object given its navitia name, for objects not predefined in this package
+func NewObject(name string) Object {
+	return Object{
+		name: name,
+		ID:   Attribute{object: name, name: "id"},
+		Name: Attribute{object: name, name: "name"},
+		Code: Attribute{object: name, name: "code"},
+	}
+}
+
+// check checks the validity of the object's name
+func (o Object) check() error {
+	if !identifierRegexp.MatchString(o.name) {
+		return errors.Errorf("filter: invalid object name %q", o.name)
+	}
+	return nil
+}
+
+// Attr returns an attribute of the object, for attributes not predefined in this package
+func (o Object) Attr(name string) Attribute {
+	return Attribute{object: o.name, name: name}
+}
+
+// DWithin returns a filter matching the objects located less than distance meters away from coords.
+//
+// Only objects with coordinates, such as stop points & stop areas, can be filtered that way.
+func (o Object) DWithin(coords types.Coordinates, distance uint) Filter {
+	if err := o.check(); err != nil {
+		return Filter{err: err}
+	}
+	if distance == 0 {
+		return Filter{err: errors.Errorf("filter: DWITHIN on %s with a null distance", o.name)}
+	}
+	lon := strconv.FormatFloat(coords.Longitude, 'f', -1, 64)
+	lat := strconv.FormatFloat(coords.Latitude, 'f', -1, 64)
+	return Filter{expr: o.name + ".coord DWITHIN(" + lon + ", " + lat + ", " + strconv.FormatUint(uint64(distance), 10) + ")"}
+}
+
+// Call returns a filter calling a method of the object, such as has_code or has_headsign.
+func (o Object) Call(method string, args ...string) Filter {
+	if err := o.check(); err != nil {
+		return Filter{err: err}
+	}
+	if !identifierRegexp.MatchString(method) {
+		return Filter{err: errors.Errorf("filter: invalid method name %q on %s", method, o.name)}
+	}
+	escaped := make([]string, len(args))
+	for i, arg := range args {
+		if arg == "" {
+			return Filter{err: errors.Errorf("filter: empty argument #%d to %s.%s", i, o.name, method)}
+		}
+		escaped[i] = escape(arg)
+	}
+	return Filter{expr: o.name + "." + method + "(" + strings.Join(escaped, ", ") + ")"}
+}
+
+// HasCode returns a filter matching the objects having the given code.
+//
+// See types.Code
+func (o Object) HasCode(codeType string, value string) Filter {
+	return o.Call("has_code", codeType, value)
+}
+
+// HasHeadsign returns a filter matching the objects having the given headsign.
+func (o Object) HasHeadsign(headsign string) Filter {
+	return o.Call("has_headsign", headsign)
+}
+
+// HasDisruption returns a filter matching the objects impacted by a disruption.
+func (o Object) HasDisruption() Filter {
+	return o.Call("has_disruption")
+}
+
+// An Attribute is an attribute of an Object, on which comparisons can be made.
+type Attribute struct {
+	object string
+	name   string
+}
+
+// compare returns a filter comparing the attribute with value
+func (a Attribute) compare(op string, value string) Filter {
+	switch {
+	case !identifierRegexp.MatchString(a.object):
+		return Filter{err: errors.Errorf("filter: invalid object name %q", a.object)}
+	case !identifierRegexp.MatchString(a.name):
+		return Filter{err: errors.Errorf("filter: invalid attribute name %q on %s", a.name, a.object)}
+	case value == "":
+		return Filter{err: errors.Errorf("filter: empty value compared (%s) to %s.%s", op, a.object, a.name)}
+	}
+	return Filter{expr: a.object + "." + a.name + op + escape(value)}
+}
+
+// Eq returns a filter matching the objects whose attribute is equal to value
+func (a Attribute) Eq(value string) Filter {
+	return a.compare("=", value)
+}
+
+// Ne returns a filter matching the objects whose attribute isn't equal to value
+func (a Attribute) Ne(value string) Filter {
+	return a.compare("<>", value)
+}
+
+// Lt returns a filter matching the objects whose attribute is less than value
+func (a Attribute) Lt(value string) Filter {
+	return a.compare("<", value)
+}
+
+// Le returns a filter matching the objects whose attribute is less than or equal to value
+func (a Attribute) Le(value string) Filter {
+	return a.compare("<=", value)
+}
+
+// Gt returns a filter matching the objects whose attribute is greater than value
+func (a Attribute) Gt(value string) Filter {
+	return a.compare(">", value)
+}
+
+// Ge returns a filter matching the objects whose attribute is greater than or equal to value
+func (a Attribute) Ge(value string) Filter {
+	return a.compare(">=", value)
+}
@@ -0,0 +1,176 @@
+package navitia
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/aabizri/navitia/types"
+	"github.com/pkg/errors"
+)
+
+// IsochroneResults contains the results of an Isochrone request
+//
+// There is one types.Isochrone per duration band requested, ordered by increasing duration.
+type IsochroneResults struct {
+	Isochrones []types.Isochrone `json:"isochrones"`
+
+	Paging Paging `json:"links"`
+
+	// Context holds the timezone of the region, in which the date times of the results are expressed
+	Context types.Context `json:"context"`
+
+	Logging `json:"-"`
+
+	session *Session
+}
+
+// Count returns the number of isochrones available in an IsochroneResults
+func (ir *IsochroneResults) Count() int {
+	return len(ir.Isochrones)
+}
+
+// IsochroneRequest contain the parameters needed to make an Isochrone request
+type IsochroneRequest struct {
+	// Exactly one of From or To must be defined
+	// When To is given, the isochrones are computed backwards: the zones from which To is reachable.
+	From types.ID
+	To   types.ID
+
+	// When do you want to depart ? Or, when To is used, when do you want to arrive at your destination.
+	Date time.Time
+
+	// MinDuration and MaxDuration delimit the zone to be computed
+	// MaxDuration is mandatory unless BoundaryDurations is given
+	MinDuration time.Duration
+	MaxDuration time.Duration
+
+	// BoundaryDurations splits the zone into multiple duration bands, one isochrone being returned per band
+	// For example, giving 10, 20 & 30 minutes will return the [10;20] & [20;30] minutes isochrones
+	BoundaryDurations []time.Duration
+
+	// The traveller's type
+	Traveler types.TravelerType
+
+	// Define the freshness of data to use to compute isochrones
+	Freshness types.DataFreshness
+
+	// Forbidden public transport objects
+	Forbidden []types.ID
+
+	// Force the first section mode if it isn't a public transport mode
+	// Note: The parameter is inclusive, not exclusive. As such if you want to forbid a mode you have to include all modes except that one.
+	FirstSectionModes []string
+
+	// Same, but for the last section
+	LastSectionModes []string
+
+	// Wheelchair restricts the answer to accessible public transports
+	Wheelchair bool
+
+	// local is set once the date times are expressed in the region's timezone, see localQuery
+	local bool
+}
+
+// in returns the request with its date times expressed in the given location, see localQuery
+func (req IsochroneRequest) in(loc *time.Location) query {
+	if loc != nil {
+		req.Date = req.Date.In(loc)
+		req.local = true
+	}
+	return req
+}
+
+// toURL formats an isochrone request to url
+func (req IsochroneRequest) toURL() (url.Values, error) {
+	params := url.Values{}
+
+	// Check the from and to, as only one of them can be given
+	switch {
+	case req.From == "" && req.To == "":
+		return params, errors.New("no From nor To given, one is needed")
+	case req.From != "" && req.To != "":
+		return params, errors.New("both From and To given, only one is allowed")
+	case req.From != "":
+		params.Add("from", string(req.From))
+	default:
+		params.Add("to", string(req.To))
+	}
+
+	// There must be an upper bound given
+	if req.MaxDuration == 0 && len(req.BoundaryDurations) == 0 {
+		return params, errors.New("no MaxDuration nor BoundaryDurations given, one is needed")
+	}
+
+	if datetime := req.Date; !datetime.IsZero() {
+		str := formatRequestDateTime(datetime, req.local)
+		params.Add("datetime", str)
+	}
+
+	// min_duration & max_duration
+	if min := req.MinDuration; min != 0 {
+		params.Add("min_duration", strconv.FormatInt(int64(min/time.Second), 10))
+	}
+	if max := req.MaxDuration; max != 0 {
+		params.Add("max_duration", strconv.FormatInt(int64(max/time.Second), 10))
+	}
+
+	// boundary_duration[]
+	for _, d := range req.BoundaryDurations {
+		params.Add("boundary_duration[]", strconv.FormatInt(int64(d/time.Second), 10))
+	}
+
+	if traveler := req.Traveler; traveler != "" {
+		params.Add("traveler_type", string(traveler))
+	}
+
+	if freshness := req.Freshness; freshness != "" {
+		params.Add("data_freshness", string(freshness))
+	}
+
+	for _, id := range req.Forbidden {
+		params.Add("forbidden_uris[]", string(id))
+	}
+
+	for _, mode := range req.FirstSectionModes {
+		params.Add("first_section_mode[]", mode)
+	}
+
+	for _, mode := range req.LastSectionModes {
+		params.Add("last_section_mode[]", mode)
+	}
+
+	if req.Wheelchair {
+		params.Add("wheelchair", "true")
+	}
+
+	return params, nil
+}
+
+// isochrones is the internal function used by Isochrones functions
+func (s *Session) isochrones(ctx context.Context, url string, req IsochroneRequest) (*IsochroneResults, error) {
+	var results = &IsochroneResults{session: s}
+	err := s.request(ctx, url, req, results)
+	return results, err
+}
+
+const isochronesEndpoint string = "isochrones"
+
+// Isochrones computes a list of isochrones according to the parameters given
+func (s *Session) Isochrones(ctx context.Context, req IsochroneRequest) (*IsochroneResults, error) {
+	// Create the URL
+	url := s.APIURL + "/" + isochronesEndpoint
+
+	// Call
+	return s.isochrones(ctx, url, req)
+}
+
+// Isochrones computes a list of isochrones according to the parameters given in a specific scope
+func (scope *Scope) Isochrones(ctx context.Context, req IsochroneRequest) (*IsochroneResults, error) {
+	// Create the URL
+	url := scope.session.APIURL + "/coverage/" + string(scope.region) + "/" + isochronesEndpoint
+
+	// Call
+	return scope.session.isochrones(ctx, url, req)
+}
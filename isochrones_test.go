@@ -0,0 +1,45 @@
+package navitia
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aabizri/navitia/types"
+)
+
+func Test_IsochroneRequest_toUrl(t *testing.T) {
+	// Declare this test to be run in parallel
+	t.Parallel()
+
+	// Without From nor To, or with both, toURL should fail
+	if _, err := (IsochroneRequest{MaxDuration: time.Hour}).toURL(); err == nil {
+		t.Errorf("error in IsochroneRequest.toURL: expected an error when no From nor To is given, got none")
+	}
+	if _, err := (IsochroneRequest{From: "a", To: "b", MaxDuration: time.Hour}).toURL(); err == nil {
+		t.Errorf("error in IsochroneRequest.toURL: expected an error when both From and To are given, got none")
+	}
+
+	// Boundary durations should be encoded in seconds, once per boundary
+	req := IsochroneRequest{
+		From:              types.Coordinates{Latitude: 48.867305, Longitude: 2.352005}.ID(),
+		BoundaryDurations: []time.Duration{10 * time.Minute, 20 * time.Minute},
+	}
+	params, err := req.toURL()
+	if err != nil {
+		t.Fatalf("error in IsochroneRequest.toURL: %v\n\tReceived: %#v", err, params)
+	}
+	if got, expected := params["boundary_duration[]"], []string{"600", "1200"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("error in IsochroneRequest.toURL: expected boundary_duration[] to be %v, got %v", expected, got)
+	}
+}
+
+// Test_IsochroneResults_Unmarshal tests unmarshalling for IsochroneResults.
+// As the unmarshalling is done by encoding/json, this allows us to check that the input can be reliably unmarshalled into the structure we have for it.
+//
+// This launches both a "correct" and "incorrect" subtest, allowing us to test both cases.
+// 	If we expect no errors but we get one, the test fails
+//	If we expect an error but we don't get one, the test fails
+func Test_IsochroneResults_Unmarshal(t *testing.T) {
+	testUnmarshal(t, testData["isochrones"], reflect.TypeOf(IsochroneResults{}))
+}
@@ -18,6 +18,12 @@ type JourneyResults struct {
 
 	Paging Paging `json:"links"`
 
+	// Pagination describes the position of this page in the results
+	Pagination Pagination `json:"pagination"`
+
+	// Context holds the timezone of the region, in which the date times of the results are expressed
+	Context types.Context `json:"context"`
+
 	Logging `json:"-"`
 
 	session *Session
@@ -86,6 +92,18 @@ type JourneyRequest struct {
 
 	// Wheelchair restricts the answer to accessible public transports
 	Wheelchair bool
+
+	// local is set once the date times are expressed in the region's timezone, see localQuery
+	local bool
+}
+
+// in returns the request with its date times expressed in the given location, see localQuery
+func (req JourneyRequest) in(loc *time.Location) query {
+	if loc != nil {
+		req.Date = req.Date.In(loc)
+		req.local = true
+	}
+	return req
 }
 
 // toURL formats a journey request to url
@@ -141,7 +159,7 @@ func (req JourneyRequest) toURL() (url.Values, error) {
 	}
 
 	if datetime := req.Date; !datetime.IsZero() {
-		str := datetime.Format(types.DateTimeFormat)
+		str := formatRequestDateTime(datetime, req.local)
 		params.Add("datetime", str)
 		if req.DateIsArrival {
 			params.Add("datetime_represents", "arrival")
@@ -210,6 +228,8 @@ func (s *Session) Journeys(ctx context.Context, req JourneyRequest) (*JourneyRes
 }
 
 // Journeys computes a list of journeys according to the parameters given in a specific scope
+//
+// If the timezone of the region is known, see Scope.Location, the date of the request is converted to it before being sent.
 func (scope *Scope) Journeys(ctx context.Context, req JourneyRequest) (*JourneyResults, error) {
 	// Create the URL
 	url := scope.session.APIURL + "/coverage/" + string(scope.region) + "/" + journeysEndpoint
@@ -217,3 +237,52 @@ func (scope *Scope) Journeys(ctx context.Context, req JourneyRequest) (*JourneyR
 	// Call
 	return scope.session.journeys(ctx, url, req)
 }
+
+// ResumeJourneys requests the page of journeys a cursor points to, such as JourneyResults.Paging.Next.
+//
+// The cursor must point to the journeys endpoint of the session's API, otherwise ErrInvalidCursor is returned.
+func (s *Session) ResumeJourneys(ctx context.Context, cursor Cursor) (*JourneyResults, error) {
+	url, err := s.cursorURL(cursor, journeysEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	var results = &JourneyResults{session: s}
+	err = s.requestURL(ctx, url, results)
+	return results, err
+}
+
+// Earlier requests the journeys departing before these ones, returning nil if there are none
+func (jr *JourneyResults) Earlier(ctx context.Context) (*JourneyResults, error) {
+	return jr.follow(ctx, jr.Paging.Previous)
+}
+
+// Later requests the journeys departing after these ones, returning nil if there are none
+func (jr *JourneyResults) Later(ctx context.Context) (*JourneyResults, error) {
+	return jr.follow(ctx, jr.Paging.Next)
+}
+
+// FirstOfDay requests the first journeys of the day, returning nil if there are none
+func (jr *JourneyResults) FirstOfDay(ctx context.Context) (*JourneyResults, error) {
+	return jr.follow(ctx, jr.Paging.First)
+}
+
+// LastOfDay requests the last journeys of the day, returning nil if there are none
+func (jr *JourneyResults) LastOfDay(ctx context.Context) (*JourneyResults, error) {
+	return jr.follow(ctx, jr.Paging.Last)
+}
+
+// follow requests the journeys a cursor of the results points to, returning nil if the cursor is empty
+//
+// Like NextPage, the link is followed as-is, see Session.requestCursor.
+func (jr *JourneyResults) follow(ctx context.Context, cursor Cursor) (*JourneyResults, error) {
+	if cursor == "" || jr.session == nil {
+		return nil, nil
+	}
+	res := &JourneyResults{session: jr.session}
+	err := jr.session.requestCursor(ctx, cursor, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
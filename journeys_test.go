@@ -2,7 +2,12 @@ package navitia
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/aabizri/navitia/types"
@@ -56,13 +61,12 @@ func Test_Journeys_Paging(t *testing.T) {
 	}
 
 	var i uint
-	for i = 0; res.Paging.Next != nil && i < 6; i++ {
-		p := JourneyResults{}
-		err = res.Paging.Next(ctx, testSession, &p)
+	for i = 0; res.Paging.Next != "" && i < 6; i++ {
+		p, err := res.NextPage(ctx)
 		if err != nil {
-			t.Fatalf("error in call #%d to res.Paging.Next: %v\n\tReceived: %#v", i, err, p)
+			t.Fatalf("error in call #%d to res.NextPage: %v\n\tReceived: %#v", i, err, p)
 		}
-		res = &p
+		res = p
 	}
 	t.Logf("Paging finished with %d iterations", i)
 }
@@ -75,3 +79,94 @@ func Test_Journeys_Paging(t *testing.T) {
 func Test_JourneysResults_Unmarshal(t *testing.T) {
 	testUnmarshal(t, testData["journeys"], reflect.TypeOf(JourneyResults{}))
 }
+
+// Test_JourneyResults_Unmarshal_links checks that the prev, next, first & last links of the test data are kept
+func Test_JourneyResults_Unmarshal_links(t *testing.T) {
+	for name, b := range testData["journeys"].correct {
+		var res JourneyResults
+		if err := json.Unmarshal(b, &res); err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		p := res.Paging
+		if p.Previous == "" || p.Next == "" || p.First == "" || p.Last == "" {
+			t.Errorf("%s: expected all the links to be kept, got %+v", name, p)
+		}
+	}
+}
+
+// Test_JourneyResults_Earlier_Later checks the helpers paging through the day
+func Test_JourneyResults_Earlier_Later(t *testing.T) {
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		link := func(typ string, datetime string) string {
+			return fmt.Sprintf(`{"type": %q, "href": "%s/journeys?datetime=%s"}`, typ, ts.URL, datetime)
+		}
+		datetime := r.URL.Query().Get("datetime")
+		if datetime == "" {
+			datetime = "20171010T120000"
+		}
+		links := []string{link("prev", "20171010T110000"), link("next", "20171010T130000"), link("first", "20171010T000000"), link("last", "20171010T235900")}
+		fmt.Fprintf(w, `{"journeys": [{"requested_date_time": %q}], "links": [%s]}`, datetime, strings.Join(links, ","))
+	}))
+	defer ts.Close()
+
+	session, err := NewSession("", WithBaseURL(ts.URL))
+	if err != nil {
+		t.Fatalf("error while creating session: %v", err)
+	}
+	ctx := context.Background()
+	res, err := session.Journeys(ctx, JourneyRequest{From: "a", To: "b"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	helpers := map[string]func(*JourneyResults, context.Context) (*JourneyResults, error){
+		"20171010T110000": (*JourneyResults).Earlier,
+		"20171010T130000": (*JourneyResults).Later,
+		"20171010T000000": (*JourneyResults).FirstOfDay,
+		"20171010T235900": (*JourneyResults).LastOfDay,
+	}
+	for expected, helper := range helpers {
+		got, err := helper(res, ctx)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", expected, err)
+		}
+		if got.Count() != 1 || got.Journeys[0].Requested.Format("20060102T150405") != expected {
+			t.Errorf("%s: unexpected journeys %+v", expected, got.Journeys)
+		}
+	}
+
+	// Without links, there are no earlier journeys
+	if got, err := (&JourneyResults{session: session}).Earlier(ctx); got != nil || err != nil {
+		t.Errorf("expected no results nor error, got %v & %v", got, err)
+	}
+}
+
+// Test_JourneyResults_Later_proxied checks that the helpers paging through the day follow the links as NextPage does, even if they point to another host than the session's API
+func Test_JourneyResults_Later_proxied(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"journeys": [{"requested_date_time": "20171010T130000"}]}`)
+	}))
+	defer backend.Close()
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"journeys": [], "links": [{"type": "next", "href": "%s/v1/journeys?datetime=20171010T130000"}]}`, backend.URL)
+	}))
+	defer proxy.Close()
+
+	session, err := NewSession("", WithBaseURL(proxy.URL+"/v1"))
+	if err != nil {
+		t.Fatalf("error while creating session: %v", err)
+	}
+	ctx := context.Background()
+	res, err := session.Journeys(ctx, JourneyRequest{From: "a", To: "b"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	later, err := res.Later(ctx)
+	if err != nil {
+		t.Fatalf("error while requesting later journeys: %v", err)
+	}
+	if later == nil || later.Count() != 1 || later.Journeys[0].Requested.Format("20060102T150405") != "20171010T130000" {
+		t.Errorf("expected the later journeys, got %+v", later)
+	}
+}
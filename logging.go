@@ -1,12 +1,67 @@
 package navitia
 
-import "time"
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptrace"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
 
-// Logging stores logging info
+// Logging stores logging info, a diagnostics record of the request which produced the results
+//
+// It allows to tell a slow navitia computation (see Timings.FirstByte & ServerTiming) from a slow network (see Timings.DNS, Timings.Connect & Timings.TLS).
 type Logging struct {
 	Created  time.Time
 	Sent     time.Time
 	Received time.Time
+
+	// Attempts is the number of attempts made, see RetryPolicy
+	Attempts uint
+
+	// Cached is true if the results were served by the session's Cache
+	Cached bool
+
+	// Shared is true if the results were decoded from the response to an identical concurrent call, see Session.Coalesce
+	Shared bool
+
+	// URL is the requested URL, including the query
+	URL string
+
+	// StatusCode is the HTTP status code of the response, it is 0 if the results were served by the cache
+	StatusCode int
+
+	// ResponseSize is the size of the response body in bytes, as read
+	ResponseSize int64
+
+	// Timings are the transport timings of the last attempt
+	Timings Timings
+
+	// ServerTiming are the durations reported by the server through the Server-Timing header of the last response, by metric name
+	ServerTiming map[string]time.Duration
+}
+
+// Timings are the transport timings of a request, measured with net/http/httptrace
+//
+// A timing is zero if its step didn't happen, for example DNS, Connect & TLS when a connection is reused.
+type Timings struct {
+	// DNS is the duration of the DNS lookup
+	DNS time.Duration
+
+	// Connect is the duration of the TCP connection
+	Connect time.Duration
+
+	// TLS is the duration of the TLS handshake
+	TLS time.Duration
+
+	// FirstByte is the duration between the request being written and the first byte of the response being received.
+	// It includes the computation done by the server.
+	FirstByte time.Duration
+
+	// Reused is true if the connection was reused from a previous request
+	Reused bool
 }
 
 // creating stores creation time
@@ -14,6 +69,30 @@ func (l *Logging) creating() {
 	l.Created = time.Now()
 }
 
+// attempting counts an attempt
+func (l *Logging) attempting() {
+	l.Attempts++
+}
+
+// attempted records the transport timings of an attempt, and the server timings of its response if there is one
+func (l *Logging) attempted(timings Timings, resp *http.Response) {
+	l.Timings = timings
+	l.ServerTiming = nil
+	if resp != nil {
+		l.ServerTiming = parseServerTiming(resp.Header["Server-Timing"])
+	}
+}
+
+// caching records that the results come from the cache
+func (l *Logging) caching() {
+	l.Cached = true
+}
+
+// sharing records that the results come from a shared response
+func (l *Logging) sharing() {
+	l.Shared = true
+}
+
 // sending stores sending time
 func (l *Logging) sending() {
 	l.Sent = time.Now()
@@ -23,3 +102,109 @@ func (l *Logging) sending() {
 func (l *Logging) parsing() {
 	l.Received = time.Now()
 }
+
+// responded records the url of the request, and the status code & size of the response
+func (l *Logging) responded(url string, statusCode int, size int64) {
+	l.URL = url
+	l.StatusCode = statusCode
+	l.ResponseSize = size
+}
+
+// timingsTrace measures the Timings of a request through an httptrace.ClientTrace
+type timingsTrace struct {
+	mu sync.Mutex
+
+	dnsStart, connectStart, tlsStart, wrote time.Time
+	timings                                 Timings
+}
+
+// clientTrace returns the httptrace.ClientTrace recording the timings
+func (tt *timingsTrace) clientTrace() *httptrace.ClientTrace {
+	// since records the duration since the given start, if there is one
+	since := func(start *time.Time, d *time.Duration) {
+		tt.mu.Lock()
+		defer tt.mu.Unlock()
+		if !start.IsZero() {
+			*d = time.Since(*start)
+		}
+	}
+	// mark stores the current time
+	mark := func(t *time.Time) {
+		tt.mu.Lock()
+		defer tt.mu.Unlock()
+		*t = time.Now()
+	}
+
+	return &httptrace.ClientTrace{
+		DNSStart:             func(httptrace.DNSStartInfo) { mark(&tt.dnsStart) },
+		DNSDone:              func(httptrace.DNSDoneInfo) { since(&tt.dnsStart, &tt.timings.DNS) },
+		ConnectStart:         func(string, string) { mark(&tt.connectStart) },
+		ConnectDone:          func(string, string, error) { since(&tt.connectStart, &tt.timings.Connect) },
+		TLSHandshakeStart:    func() { mark(&tt.tlsStart) },
+		TLSHandshakeDone:     func(tls.ConnectionState, error) { since(&tt.tlsStart, &tt.timings.TLS) },
+		WroteRequest:         func(httptrace.WroteRequestInfo) { mark(&tt.wrote) },
+		GotFirstResponseByte: func() { since(&tt.wrote, &tt.timings.FirstByte) },
+		GotConn: func(info httptrace.GotConnInfo) {
+			tt.mu.Lock()
+			defer tt.mu.Unlock()
+			tt.timings.Reused = info.Reused
+		},
+	}
+}
+
+// get returns the measured timings
+func (tt *timingsTrace) get() Timings {
+	tt.mu.Lock()
+	defer tt.mu.Unlock()
+	return tt.timings
+}
+
+// parseServerTiming parses the values of Server-Timing headers, such as `db;dur=53, app;dur=47.2`, returning nil if there are none
+//
+// Metrics without a duration are ignored.
+func parseServerTiming(values []string) map[string]time.Duration {
+	var timings map[string]time.Duration
+	for _, value := range values {
+		for _, metric := range splitUnquoted(value, ',') {
+			params := splitUnquoted(metric, ';')
+			name := strings.TrimSpace(params[0])
+			if name == "" {
+				continue
+			}
+			for _, param := range params[1:] {
+				kv := strings.SplitN(param, "=", 2)
+				if len(kv) != 2 || strings.TrimSpace(kv[0]) != "dur" {
+					continue
+				}
+				ms, err := strconv.ParseFloat(strings.Trim(strings.TrimSpace(kv[1]), `"`), 64)
+				if err != nil {
+					continue
+				}
+				if timings == nil {
+					timings = make(map[string]time.Duration)
+				}
+				timings[name] = time.Duration(ms * float64(time.Millisecond))
+			}
+		}
+	}
+	return timings
+}
+
+// splitUnquoted splits s around each instance of sep which isn't within double quotes
+func splitUnquoted(s string, sep rune) []string {
+	var (
+		parts  []string
+		quoted bool
+		start  int
+	)
+	for i, r := range s {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case r == sep && !quoted:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
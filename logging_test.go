@@ -0,0 +1,91 @@
+package navitia
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_parseServerTiming(t *testing.T) {
+	// Declare this test to be run in parallel
+	t.Parallel()
+
+	tests := []struct {
+		values   []string
+		expected map[string]time.Duration
+	}{
+		{nil, nil},
+		{[]string{"miss"}, nil},
+		{[]string{"db;dur=53"}, map[string]time.Duration{"db": 53 * time.Millisecond}},
+		{
+			[]string{`db;dur=53, cache;desc="Cache, Read";dur=23.2`, "app;dur=47"},
+			map[string]time.Duration{
+				"db":    53 * time.Millisecond,
+				"cache": 23200 * time.Microsecond,
+				"app":   47 * time.Millisecond,
+			},
+		},
+		{[]string{"db;dur=invalid, app;dur=1"}, map[string]time.Duration{"app": time.Millisecond}},
+	}
+
+	for i, test := range tests {
+		got := parseServerTiming(test.values)
+		if !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("case #%d: expected %v, got %v", i, test.expected, got)
+		}
+	}
+}
+
+// Test_Logging checks that the diagnostics are recorded in the results' Logging
+func Test_Logging(t *testing.T) {
+	const body = `{"departures": []}`
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Server-Timing", "navitia;dur=12.5")
+		fmt.Fprint(w, body)
+	}))
+	defer ts.Close()
+
+	session, err := NewSession("", WithBaseURL(ts.URL))
+	if err != nil {
+		t.Fatalf("error while creating session: %v", err)
+	}
+
+	ctx := context.Background()
+	res, err := session.Scope("fr-idf").DeparturesSA(ctx, ConnectionsRequest{}, "stop_area:RAT:SA:GDLYO")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.HasPrefix(res.URL, ts.URL+"/coverage/fr-idf/stop_areas/stop_area:RAT:SA:GDLYO/departures") {
+		t.Errorf("unexpected URL %s", res.URL)
+	}
+	if res.StatusCode != http.StatusOK {
+		t.Errorf("expected status code 200, got %d", res.StatusCode)
+	}
+	if res.ResponseSize != int64(len(body)) {
+		t.Errorf("expected a response size of %d, got %d", len(body), res.ResponseSize)
+	}
+	if got := res.ServerTiming["navitia"]; got != 12500*time.Microsecond {
+		t.Errorf("expected a server timing of 12.5ms, got %v", got)
+	}
+	if res.Timings.Connect == 0 || res.Timings.FirstByte == 0 {
+		t.Errorf("expected the connection & first byte timings to be recorded, got %+v", res.Timings)
+	}
+	if res.Timings.Reused {
+		t.Errorf("expected the first connection not to be reused")
+	}
+
+	// The second request reuses the connection
+	res, err = session.Scope("fr-idf").DeparturesSA(ctx, ConnectionsRequest{}, "stop_area:RAT:SA:GDLYO")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !res.Timings.Reused || res.Timings.Connect != 0 {
+		t.Errorf("expected the second connection to be reused, got %+v", res.Timings)
+	}
+}
@@ -0,0 +1,111 @@
+package navitia
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aabizri/navitia/types"
+	"github.com/pkg/errors"
+)
+
+// A Call is a request made by a Session, as seen by a Middleware.
+type Call struct {
+	// Endpoint is the endpoint of the request, such as "journeys" or "places"
+	Endpoint string
+
+	// Region is the region of the request, such as "fr-idf", or empty if it isn't scoped to one
+	Region types.ID
+
+	// URL is the URL of the request, without the encoded Request
+	URL string
+
+	// Request is the typed request, such as a JourneyRequest or a PlacesRequest, it is encoded into the URL after the middlewares run.
+	// A middleware may replace it by another request of the same type, for example to force its Freshness.
+	//
+	// It is nil for requests without parameters, such as ReverseGeocode or the paging ones, their URL being final.
+	Request interface{}
+
+	// Header is added to the HTTP request
+	Header http.Header
+
+	// Results is the typed results populated by the call, such as a *JourneyResults or a *PlacesResults.
+	// A middleware short-circuiting the call may populate it itself.
+	//
+	// For streamed requests, such as JourneysStream, the streamed items aren't kept in the Results.
+	Results interface{}
+
+	// StatusCode & ResponseSize describe the response, once the call is executed
+	// The StatusCode is 0 if the response was served by the cache, or if there was no response.
+	// The ResponseSize is the size of the body in bytes, as read.
+	StatusCode   int
+	ResponseSize int64
+
+	// stream, if not nil, streams the decoding of the results
+	stream *streamDecoder
+
+	// deadlined is set if the request wasn't retried because of the deadline of its context
+	deadlined bool
+}
+
+// A Handler executes a Call
+type Handler func(ctx context.Context, call *Call) error
+
+// A Middleware wraps a Handler, allowing to see and mutate a Call before it is executed, and to see its decoded Results after.
+//
+// For example, a logging middleware:
+//
+//	func(next navitia.Handler) navitia.Handler {
+//		return func(ctx context.Context, call *navitia.Call) error {
+//			err := next(ctx, call)
+//			log.Printf("%s: %v", call.Endpoint, err)
+//			return err
+//		}
+//	}
+//
+// A middleware may also short-circuit the call, by not calling next.
+type Middleware func(next Handler) Handler
+
+// call executes a Call through the session's middlewares, the first one being the outermost
+func (s *Session) call(ctx context.Context, call *Call) error {
+	call.Endpoint = endpointOf(call.URL)
+	call.Region = regionOf(call.URL)
+	if call.Header == nil {
+		call.Header = http.Header{}
+	}
+
+	handler := Handler(s.execute)
+	for i := len(s.Middlewares) - 1; i >= 0; i-- {
+		handler = s.Middlewares[i](handler)
+	}
+	return handler(ctx, call)
+}
+
+// execute is the innermost Handler: it encodes the request, executes it and decodes its results
+func (s *Session) execute(ctx context.Context, call *Call) error {
+	res, ok := call.Results.(results)
+	if !ok {
+		return errors.Errorf("invalid results type %T for call to %s", call.Results, call.URL)
+	}
+
+	url := call.URL
+	if call.Request != nil {
+		query, ok := call.Request.(query)
+		if !ok {
+			return errors.Errorf("invalid request type %T for call to %s", call.Request, call.URL)
+		}
+
+		// Express its date times in the region's timezone, if known
+		if lq, ok := query.(localQuery); ok {
+			query = lq.in(s.location(call.Region))
+		}
+
+		// Encode the parameters
+		values, err := query.toURL()
+		if err != nil {
+			return errors.Wrap(err, "error while retrieving url values to be encoded")
+		}
+		url += "?" + values.Encode()
+	}
+
+	return s.fetch(ctx, url, call, res)
+}
@@ -0,0 +1,95 @@
+package navitia
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aabizri/navitia/types"
+)
+
+func Test_Session_Middlewares(t *testing.T) {
+	var lastRequest *http.Request
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastRequest = r
+		fmt.Fprint(w, `{"departures": [], "places": []}`)
+	}))
+	defer ts.Close()
+
+	s, err := NewCustom("", ts.URL, http.DefaultClient)
+	if err != nil {
+		t.Fatalf("error while creating session: %v", err)
+	}
+	ctx := context.Background()
+
+	var order []string
+	trace := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context, call *Call) error {
+				order = append(order, name+">")
+				err := next(ctx, call)
+				order = append(order, "<"+name)
+				return err
+			}
+		}
+	}
+
+	// Forces realtime departures & adds a header
+	realtime := func(next Handler) Handler {
+		return func(ctx context.Context, call *Call) error {
+			if req, ok := call.Request.(ConnectionsRequest); ok {
+				req.Freshness = types.DataFreshnessRealTime
+				call.Request = req
+			}
+			call.Header.Set("X-Test", call.Endpoint+" "+string(call.Region))
+			return next(ctx, call)
+		}
+	}
+
+	s.Middlewares = []Middleware{trace("outer"), trace("inner"), realtime}
+
+	t.Run("order & mutation", func(t *testing.T) {
+		res, err := s.Scope("sandbox").DeparturesSA(ctx, ConnectionsRequest{}, "stop_area:A")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if res == nil {
+			t.Fatalf("expected results")
+		}
+		if fmt.Sprint(order) != "[outer> inner> <inner <outer]" {
+			t.Errorf("unexpected middleware order: %v", order)
+		}
+		if got := lastRequest.URL.Query().Get("data_freshness"); got != "realtime" {
+			t.Errorf("expected the request to be mutated, got data_freshness=%q", got)
+		}
+		if got := lastRequest.Header.Get("X-Test"); got != "departures sandbox" {
+			t.Errorf("expected the header to be injected with the endpoint & region, got %q", got)
+		}
+	})
+
+	t.Run("short-circuit", func(t *testing.T) {
+		lastRequest = nil
+		s.Middlewares = []Middleware{func(next Handler) Handler {
+			return func(ctx context.Context, call *Call) error {
+				if res, ok := call.Results.(*PlacesResults); ok {
+					res.Places = []types.Container{{ID: "stop_area:A"}}
+					return nil
+				}
+				return next(ctx, call)
+			}
+		}}
+
+		res, err := s.Places(ctx, PlacesRequest{Query: "test"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if lastRequest != nil {
+			t.Errorf("expected no request to be made")
+		}
+		if res.Len() != 1 {
+			t.Errorf("expected the results populated by the middleware, got %#v", res)
+		}
+	})
+}
@@ -57,6 +57,17 @@ var typesList = []string{
 	"coverage",
 	"places",
 	"connections",
+	"isochrones",
+	"traffic_reports",
+	"line_reports",
+	"stop_schedules",
+	"route_schedules",
+	"lines",
+	"stop_areas",
+	"vehicle_journeys",
+	"pt_objects",
+	"places_nearby",
+	"reverse_geocode",
 }
 
 // listCategoryDirs retrieves the subdirectories under the main testdata directory
@@ -0,0 +1,163 @@
+package navitia
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"unsafe"
+
+	"github.com/aabizri/navitia/filter"
+	"github.com/aabizri/navitia/types"
+	"github.com/pkg/errors"
+)
+
+// PlacesNearbyResults holds the results of a places nearby request.
+//
+// Each place has its distance to the requested coordinates set, see types.Container.Distance
+type PlacesNearbyResults struct {
+	Places []types.Container `json:"places_nearby"`
+
+	Paging Paging `json:"links"`
+
+	// Pagination describes the position of this page in the results
+	Pagination Pagination `json:"pagination"`
+
+	// Context holds the timezone of the region, in which the date times of the results are expressed
+	Context types.Context `json:"context"`
+
+	Logging `json:"-"`
+
+	session *Session
+}
+
+// Count returns the number of places available in a PlacesNearbyResults
+func (pnr *PlacesNearbyResults) Count() int {
+	return len(pnr.Places)
+}
+
+// PlacesNearbyRequest contains the optional parameters for a places nearby request.
+type PlacesNearbyRequest struct {
+	// Distance is the maximum distance in meters of the places to the coordinates
+	//
+	// Default value is 500 meters
+	Distance uint
+
+	// Types are the type of objects to query
+	// It can either be a stop_area, a stop_point, an address, a poi or an administrative_region
+	Types []string
+
+	// Filter restricts the results to the objects matching it, see the filter package
+	Filter filter.Filter
+
+	// The maximum amount of places per page
+	Count uint
+
+	// The page to retrieve
+	StartPage uint
+
+	// ForbiddenURIs
+	Forbidden []types.ID
+
+	// Enables GeoJSON data in the reply. GeoJSON objects can be VERY large ! >1MB.
+	Geo bool
+}
+
+func (req PlacesNearbyRequest) toURL() (url.Values, error) {
+	values := url.Values{}
+
+	if distance := req.Distance; distance != 0 {
+		values.Add("distance", strconv.FormatUint(uint64(distance), 10))
+	}
+
+	if len(req.Types) != 0 {
+		values["type[]"] = req.Types
+	}
+
+	// Add the filter
+	if f := req.Filter; !f.Empty() {
+		if err := f.Validate(); err != nil {
+			return nil, errors.Wrap(err, "invalid filter")
+		}
+		values.Add("filter", f.String())
+	}
+
+	if count := req.Count; count != 0 {
+		values.Add("count", strconv.FormatUint(uint64(count), 10))
+	}
+	if page := req.StartPage; page != 0 {
+		values.Add("start_page", strconv.FormatUint(uint64(page), 10))
+	}
+
+	// Deal with the forbidden URIs
+	if forbidden := req.Forbidden; len(forbidden) != 0 {
+		magic := *(*[]string)(unsafe.Pointer(&forbidden))
+		values["forbidden_uris[]"] = magic
+	}
+
+	// Add GEO
+	if !req.Geo {
+		values.Add("disable_geojson", "true")
+	}
+
+	return values, nil
+}
+
+// ReverseGeocodeResults holds the results of a reverse geocoding request.
+type ReverseGeocodeResults struct {
+	// Address is the address found at the coordinates
+	Address types.Address `json:"address"`
+
+	// Regions lists the IDs of the regions covering the coordinates
+	Regions []types.ID `json:"regions"`
+
+	// Context holds the timezone of the region, in which the date times of the results are expressed
+	Context types.Context `json:"context"`
+
+	Logging `json:"-"`
+
+	session *Session
+}
+
+const (
+	coordsEndpoint       string = "coords"
+	placesNearbyEndpoint        = "places_nearby"
+)
+
+// PlacesNearby requests the places around the given coordinates within a region, such as the stops around you.
+//
+// It is context aware.
+func (scope *Scope) PlacesNearby(ctx context.Context, req PlacesNearbyRequest, coords types.Coordinates) (*PlacesNearbyResults, error) {
+	// Create the URL
+	url := scope.session.APIURL + "/coverage/" + string(scope.region) + "/" + coordsEndpoint + "/" + string(coords.ID()) + "/" + placesNearbyEndpoint
+
+	// Call
+	var results = &PlacesNearbyResults{session: scope.session}
+	err := scope.session.request(ctx, url, req, results)
+	return results, err
+}
+
+// PlacesNearbyC requests the places around the given coordinates, the region being inferred from them.
+//
+// It is context aware.
+func (s *Session) PlacesNearbyC(ctx context.Context, req PlacesNearbyRequest, coords types.Coordinates) (*PlacesNearbyResults, error) {
+	// Create the URL
+	url := s.APIURL + "/" + coordsEndpoint + "/" + string(coords.ID()) + "/" + placesNearbyEndpoint
+
+	// Call
+	var results = &PlacesNearbyResults{session: s}
+	err := s.request(ctx, url, req, results)
+	return results, err
+}
+
+// ReverseGeocode requests the address at the given coordinates, along with the regions covering them.
+//
+// It is context aware.
+func (s *Session) ReverseGeocode(ctx context.Context, coords types.Coordinates) (*ReverseGeocodeResults, error) {
+	// Create the URL
+	url := s.APIURL + "/" + coordsEndpoint + "/" + string(coords.ID())
+
+	// Call
+	var results = &ReverseGeocodeResults{session: s}
+	err := s.requestURL(ctx, url, results)
+	return results, err
+}
@@ -0,0 +1,39 @@
+package navitia
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_PlacesNearbyRequest_toUrl(t *testing.T) {
+	// Declare this test to be run in parallel
+	t.Parallel()
+
+	req, err := PlacesNearbyRequest{Geo: true}.toURL()
+	if err != nil {
+		t.Fatalf("error in PlacesNearbyRequest.toURL: %v\n\tReceived: %#v", err, req)
+	}
+	if len(req) != 0 {
+		t.Fatalf("error in PlacesNearbyRequest.toURL: toURL created fields for non-specified parameters\n\tReceived: %#v", req)
+	}
+}
+
+// Test_PlacesNearbyResults_Unmarshal tests unmarshalling for PlacesNearbyResults.
+// As the unmarshalling is done by encoding/json, this allows us to check that the input can be reliably unmarshalled into the structure we have for it.
+//
+// This launches both a "correct" and "incorrect" subtest, allowing us to test both cases.
+// 	If we expect no errors but we get one, the test fails
+//	If we expect an error but we don't get one, the test fails
+func Test_PlacesNearbyResults_Unmarshal(t *testing.T) {
+	testUnmarshal(t, testData["places_nearby"], reflect.TypeOf(PlacesNearbyResults{}))
+}
+
+// Test_ReverseGeocodeResults_Unmarshal tests unmarshalling for ReverseGeocodeResults.
+// As the unmarshalling is done by encoding/json, this allows us to check that the input can be reliably unmarshalled into the structure we have for it.
+//
+// This launches both a "correct" and "incorrect" subtest, allowing us to test both cases.
+// 	If we expect no errors but we get one, the test fails
+//	If we expect an error but we don't get one, the test fails
+func Test_ReverseGeocodeResults_Unmarshal(t *testing.T) {
+	testUnmarshal(t, testData["reverse_geocode"], reflect.TypeOf(ReverseGeocodeResults{}))
+}
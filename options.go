@@ -0,0 +1,189 @@
+package navitia
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultTimeout is the timeout of the HTTP client created by NewSession when none is given
+const DefaultTimeout = 30 * time.Second
+
+// DefaultUserAgent is the User-Agent sent by sessions created by NewSession
+const DefaultUserAgent = "navitia-go/" + Version
+
+// A Logger logs the requests made by a Session, *log.Logger satisfies it
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// An Option configures a Session created by NewSession
+type Option func(*sessionConfig) error
+
+// sessionConfig holds the configuration built by the options, which is only applied once they've all been evaluated
+type sessionConfig struct {
+	session *Session
+	timeout time.Duration
+}
+
+// WithBaseURL sets the URL of the API, the default being https://api.navitia.io/v1
+func WithBaseURL(baseURL string) Option {
+	return func(cfg *sessionConfig) error {
+		if _, err := url.Parse(baseURL); err != nil {
+			return errors.Wrapf(err, "invalid base URL %q", baseURL)
+		}
+		cfg.session.APIURL = strings.TrimSuffix(baseURL, "/")
+		return nil
+	}
+}
+
+// withRawBaseURL sets the URL of the API as-is, as NewCustom always did
+func withRawBaseURL(baseURL string) Option {
+	return func(cfg *sessionConfig) error {
+		cfg.session.APIURL = baseURL
+		return nil
+	}
+}
+
+// WithHTTPClient sets the HTTP client used by the session
+func WithHTTPClient(client *http.Client) Option {
+	return func(cfg *sessionConfig) error {
+		if client == nil {
+			return errors.New("nil HTTP client")
+		}
+		cfg.session.client = client
+		return nil
+	}
+}
+
+// WithTimeout sets the timeout of the HTTP client, the default being DefaultTimeout.
+// The client given with WithHTTPClient isn't modified, it is copied.
+func WithTimeout(timeout time.Duration) Option {
+	return func(cfg *sessionConfig) error {
+		cfg.timeout = timeout
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with each request, the default being DefaultUserAgent
+func WithUserAgent(userAgent string) Option {
+	return func(cfg *sessionConfig) error {
+		cfg.session.UserAgent = userAgent
+		return nil
+	}
+}
+
+// WithMaxResponseSize sets the maximum size of a response in bytes, see Session.MaxResponseSize
+func WithMaxResponseSize(size int64) Option {
+	return func(cfg *sessionConfig) error {
+		if size < 0 {
+			return errors.Errorf("negative maximum response size (%d)", size)
+		}
+		cfg.session.MaxResponseSize = size
+		return nil
+	}
+}
+
+// WithEndpointMaxResponseSize sets the maximum size of a response of the given endpoint in bytes, such as "coverage", see Session.MaxResponseSizes
+func WithEndpointMaxResponseSize(endpoint string, size int64) Option {
+	return func(cfg *sessionConfig) error {
+		if size < 0 {
+			return errors.Errorf("negative maximum response size (%d) for %s", size, endpoint)
+		}
+		if cfg.session.MaxResponseSizes == nil {
+			cfg.session.MaxResponseSizes = make(map[string]int64)
+		}
+		cfg.session.MaxResponseSizes[endpoint] = size
+		return nil
+	}
+}
+
+// WithRetry sets the retry policy of the session, see RetryPolicy
+func WithRetry(policy RetryPolicy) Option {
+	return func(cfg *sessionConfig) error {
+		cfg.session.Retry = &policy
+		return nil
+	}
+}
+
+// WithCache sets the cache of the session, see Cache.
+// If ttls is nil, DefaultCacheTTLs are used.
+func WithCache(cache Cache, ttls map[string]time.Duration) Option {
+	return func(cfg *sessionConfig) error {
+		cfg.session.Cache = cache
+		cfg.session.CacheTTLs = ttls
+		return nil
+	}
+}
+
+// WithLimiter sets the rate limiter of the session, see Limiter
+func WithLimiter(limiter *Limiter) Option {
+	return func(cfg *sessionConfig) error {
+		cfg.session.Limiter = limiter
+		return nil
+	}
+}
+
+// WithCoalescing enables the coalescing of identical concurrent calls, see Session.Coalesce
+func WithCoalescing() Option {
+	return func(cfg *sessionConfig) error {
+		cfg.session.Coalesce = true
+		return nil
+	}
+}
+
+// WithLogger sets the logger of the session
+func WithLogger(logger Logger) Option {
+	return func(cfg *sessionConfig) error {
+		cfg.session.Logger = logger
+		return nil
+	}
+}
+
+// WithMiddlewares appends middlewares to the session, see Middleware
+func WithMiddlewares(middlewares ...Middleware) Option {
+	return func(cfg *sessionConfig) error {
+		cfg.session.Middlewares = append(cfg.session.Middlewares, middlewares...)
+		return nil
+	}
+}
+
+// NewSession creates a new session given an API key and options.
+//
+// Without options, it uses the navitia.io API with an HTTP client having a DefaultTimeout timeout.
+func NewSession(key string, opts ...Option) (*Session, error) {
+	cfg := &sessionConfig{
+		session: &Session{
+			APIKey:    key,
+			APIURL:    defaultAPIURL,
+			UserAgent: DefaultUserAgent,
+			created:   time.Now(),
+		},
+	}
+
+	for _, opt := range opts {
+		if err := opt(cfg); err != nil {
+			return nil, errors.Wrap(err, "NewSession: invalid option")
+		}
+	}
+
+	// Set up the client
+	s := cfg.session
+	switch {
+	case s.client == nil:
+		timeout := cfg.timeout
+		if timeout == 0 {
+			timeout = DefaultTimeout
+		}
+		s.client = &http.Client{Timeout: timeout}
+	case cfg.timeout != 0:
+		client := *s.client
+		client.Timeout = cfg.timeout
+		s.client = &client
+	}
+
+	return s, nil
+}
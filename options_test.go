@@ -0,0 +1,103 @@
+package navitia
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func Test_NewSession(t *testing.T) {
+	var userAgent string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.UserAgent()
+		fmt.Fprint(w, `{"places": []}`)
+	}))
+	defer ts.Close()
+
+	t.Run("defaults", func(t *testing.T) {
+		s, err := NewSession("key")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if s.APIURL != "https://api.navitia.io/v1" {
+			t.Errorf("unexpected default API URL %s", s.APIURL)
+		}
+		if s.client.Timeout != DefaultTimeout {
+			t.Errorf("expected a default timeout of %v, got %v", DefaultTimeout, s.client.Timeout)
+		}
+		if s.maxResponseSize("") != maxSize {
+			t.Errorf("expected a default maximum response size of %d, got %d", maxSize, s.maxResponseSize(""))
+		}
+	})
+
+	t.Run("options", func(t *testing.T) {
+		client := &http.Client{}
+		var logs bytes.Buffer
+		s, err := NewSession("key",
+			WithBaseURL(ts.URL+"/"),
+			WithHTTPClient(client),
+			WithTimeout(time.Second),
+			WithUserAgent("test-agent"),
+			WithMaxResponseSize(1000),
+			WithRetry(DefaultRetryPolicy),
+			WithCache(NewMemoryCache(10), nil),
+			WithLimiter(NewLimiter(10, 10)),
+			WithLogger(log.New(&logs, "", 0)),
+		)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if s.APIURL != ts.URL {
+			t.Errorf("expected the API URL to be %s, got %s", ts.URL, s.APIURL)
+		}
+		if client.Timeout != 0 || s.client.Timeout != time.Second {
+			t.Errorf("expected the client to be copied with the timeout set")
+		}
+		if s.Retry == nil || s.Cache == nil || s.Limiter == nil || s.maxResponseSize("") != 1000 {
+			t.Errorf("expected every option to be applied, got %#v", s)
+		}
+
+		_, err = s.Places(context.Background(), PlacesRequest{Query: "test"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if userAgent != "test-agent" {
+			t.Errorf("expected the user agent to be sent, got %q", userAgent)
+		}
+		if logs.Len() == 0 {
+			t.Errorf("expected the request to be logged")
+		}
+	})
+
+	t.Run("compatibility", func(t *testing.T) {
+		s, err := NewCustom("key", ts.URL+"/", nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if s.APIURL != ts.URL+"/" {
+			t.Errorf("expected the API URL to be kept as-is, got %s", s.APIURL)
+		}
+
+		_, err = s.Places(context.Background(), PlacesRequest{Query: "test"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if userAgent != "Go-http-client/1.1" {
+			t.Errorf("expected no user agent to be set, got %q", userAgent)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		if _, err := NewSession("key", WithHTTPClient(nil)); err == nil {
+			t.Errorf("expected an error for a nil client")
+		}
+		if _, err := NewSession("key", WithMaxResponseSize(-1)); err == nil {
+			t.Errorf("expected an error for a negative size")
+		}
+	})
+}
@@ -0,0 +1,157 @@
+/*
+Package otelnavitia instruments navitia sessions with OpenTelemetry.
+
+It provides a navitia.Middleware creating a client span per API call, tagged with the endpoint, region, status code, remote error ID and response size, and recording the latency, size & errors of the calls as metrics:
+
+	mw, err := otelnavitia.Middleware()
+	session, err := navitia.NewSession(key, navitia.WithMiddlewares(mw))
+
+By default the global tracer & meter providers are used, see WithTracerProvider & WithMeterProvider.
+
+This package depends on the OpenTelemetry API, and its tests on the OpenTelemetry SDK, which the navitia package itself doesn't: only importing otelnavitia pulls them in.
+*/
+package otelnavitia
+
+import (
+	"context"
+	"time"
+
+	"github.com/aabizri/navitia"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName is the name of the tracer & meter
+const instrumentationName = "github.com/aabizri/navitia/otelnavitia"
+
+// Attribute keys set on spans & metrics
+const (
+	EndpointKey     = attribute.Key("navitia.endpoint")
+	RegionKey       = attribute.Key("navitia.region")
+	ErrorIDKey      = attribute.Key("navitia.error.id")
+	ResponseSizeKey = attribute.Key("navitia.response.size")
+	StatusCodeKey   = attribute.Key("http.status_code")
+)
+
+// config holds the configuration built by the options
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+}
+
+// An Option configures the Middleware
+type Option func(*config)
+
+// WithTracerProvider sets the tracer provider, the default being the global one
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(cfg *config) {
+		cfg.tracerProvider = tp
+	}
+}
+
+// WithMeterProvider sets the meter provider, the default being the global one
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(cfg *config) {
+		cfg.meterProvider = mp
+	}
+}
+
+// instruments holds the metric instruments
+type instruments struct {
+	duration metric.Float64Histogram
+	size     metric.Int64Histogram
+	requests metric.Int64Counter
+	errors   metric.Int64Counter
+}
+
+// Middleware returns a navitia.Middleware instrumenting every call of a session.
+//
+// It records:
+//   - navitia.client.duration: the latency of the calls in seconds
+//   - navitia.client.response.size: the size of the responses in bytes
+//   - navitia.client.requests: the number of calls
+//   - navitia.client.errors: the number of failed calls
+func Middleware(opts ...Option) (navitia.Middleware, error) {
+	cfg := &config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	tracer := cfg.tracerProvider.Tracer(instrumentationName)
+	meter := cfg.meterProvider.Meter(instrumentationName)
+
+	// Create the instruments
+	var (
+		inst instruments
+		err  error
+	)
+	inst.duration, err = meter.Float64Histogram("navitia.client.duration", metric.WithUnit("s"), metric.WithDescription("Latency of the navitia API calls"))
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't create duration histogram")
+	}
+	inst.size, err = meter.Int64Histogram("navitia.client.response.size", metric.WithUnit("By"), metric.WithDescription("Size of the navitia API responses"))
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't create response size histogram")
+	}
+	inst.requests, err = meter.Int64Counter("navitia.client.requests", metric.WithDescription("Number of navitia API calls"))
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't create requests counter")
+	}
+	inst.errors, err = meter.Int64Counter("navitia.client.errors", metric.WithDescription("Number of failed navitia API calls"))
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't create errors counter")
+	}
+
+	return func(next navitia.Handler) navitia.Handler {
+		return func(ctx context.Context, call *navitia.Call) error {
+			// Start the span
+			attrs := []attribute.KeyValue{EndpointKey.String(call.Endpoint)}
+			if call.Region != "" {
+				attrs = append(attrs, RegionKey.String(string(call.Region)))
+			}
+			ctx, span := tracer.Start(ctx, "navitia."+call.Endpoint, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+			defer span.End()
+
+			// Call
+			start := time.Now()
+			err := next(ctx, call)
+			elapsed := time.Since(start)
+
+			// Describe the response
+			if call.StatusCode != 0 {
+				attrs = append(attrs, StatusCodeKey.Int(call.StatusCode))
+			}
+			span.SetAttributes(attrs...)
+			span.SetAttributes(ResponseSizeKey.Int64(call.ResponseSize))
+
+			// Record the error
+			if err != nil {
+				var remoteErr *navitia.RemoteError
+				if errors.As(err, &remoteErr) && remoteErr.ID != "" {
+					attrs = append(attrs, ErrorIDKey.String(string(remoteErr.ID)))
+					span.SetAttributes(ErrorIDKey.String(string(remoteErr.ID)))
+				}
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+
+			// Record the metrics
+			set := metric.WithAttributes(attrs...)
+			inst.duration.Record(ctx, elapsed.Seconds(), set)
+			inst.size.Record(ctx, call.ResponseSize, set)
+			inst.requests.Add(ctx, 1, set)
+			if err != nil {
+				inst.errors.Add(ctx, 1, set)
+			}
+
+			return err
+		}
+	}, nil
+}
@@ -0,0 +1,115 @@
+package otelnavitia
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aabizri/navitia"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestMiddleware(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("q") == "unknown" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error": {"id": "unknown_object", "message": "Invalid id"}}`)
+			return
+		}
+		fmt.Fprint(w, `{"places": []}`)
+	}))
+	defer ts.Close()
+
+	// Set up the providers
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	mw, err := Middleware(WithTracerProvider(tp), WithMeterProvider(mp))
+	if err != nil {
+		t.Fatalf("error in Middleware: %v", err)
+	}
+	session, err := navitia.NewSession("", navitia.WithBaseURL(ts.URL), navitia.WithMiddlewares(mw))
+	if err != nil {
+		t.Fatalf("error while creating session: %v", err)
+	}
+	scope := session.Scope("fr-idf")
+
+	ctx := context.Background()
+	if _, err := scope.Places(ctx, navitia.PlacesRequest{Query: "avenue"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := scope.Places(ctx, navitia.PlacesRequest{Query: "unknown"}); err == nil {
+		t.Fatalf("expected an error")
+	}
+
+	// Check the spans
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	expected := []map[attribute.Key]attribute.Value{
+		{
+			EndpointKey:   attribute.StringValue("places"),
+			RegionKey:     attribute.StringValue("fr-idf"),
+			StatusCodeKey: attribute.IntValue(200),
+		},
+		{
+			EndpointKey:   attribute.StringValue("places"),
+			RegionKey:     attribute.StringValue("fr-idf"),
+			StatusCodeKey: attribute.IntValue(404),
+			ErrorIDKey:    attribute.StringValue("unknown_object"),
+		},
+	}
+	for i, span := range spans {
+		if span.Name() != "navitia.places" {
+			t.Errorf("span #%d: unexpected name %s", i, span.Name())
+		}
+		got := make(map[attribute.Key]attribute.Value)
+		for _, kv := range span.Attributes() {
+			got[kv.Key] = kv.Value
+		}
+		for key, value := range expected[i] {
+			if got[key] != value {
+				t.Errorf("span #%d: expected %s to be %v, got %v", i, key, value.Emit(), got[key].Emit())
+			}
+		}
+		if got[ResponseSizeKey].AsInt64() == 0 {
+			t.Errorf("span #%d: expected a response size", i)
+		}
+	}
+
+	// Check the metrics
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatalf("error while collecting metrics: %v", err)
+	}
+	found := make(map[string]bool)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			found[m.Name] = true
+			if m.Name == "navitia.client.errors" {
+				sum := m.Data.(metricdata.Sum[int64])
+				var total int64
+				for _, dp := range sum.DataPoints {
+					total += dp.Value
+				}
+				if total != 1 {
+					t.Errorf("expected 1 error, got %d", total)
+				}
+			}
+		}
+	}
+	for _, name := range []string{"navitia.client.duration", "navitia.client.response.size", "navitia.client.requests", "navitia.client.errors"} {
+		if !found[name] {
+			t.Errorf("expected metric %s to be recorded", name)
+		}
+	}
+}
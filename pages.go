@@ -0,0 +1,401 @@
+package navitia
+
+import (
+	"context"
+
+	"github.com/aabizri/navitia/types"
+	"github.com/pkg/errors"
+)
+
+// This file holds the typed wrappers around the pager of every paginated results type.
+//
+// Each results type T has:
+//	- T.NextPage(ctx), returning the next page, or nil if there is none
+//	- T.Pages(ctx), returning an iterator over this page and the following ones, each one being a T
+//	- T.All(ctx, max), returning the items of this page and the following ones
+
+func (jr *JourneyResults) paging() (Paging, *Session) { return jr.Paging, jr.session }
+func (jr *JourneyResults) newPage() pager             { return &JourneyResults{session: jr.session} }
+
+// NextPage requests the next page of journeys, returning nil if there is none
+func (jr *JourneyResults) NextPage(ctx context.Context) (*JourneyResults, error) {
+	next, err := nextPage(ctx, jr)
+	res, _ := next.(*JourneyResults)
+	return res, err
+}
+
+// Pages returns an iterator through this page of journeys and the following ones, each one being a *JourneyResults
+//
+// As there is always a next page of journeys, the iteration only stops on an error, or if navitia repeats a page.
+func (jr *JourneyResults) Pages(ctx context.Context) *Pages { return newPages(ctx, jr) }
+
+// All returns the journeys of this page and the following ones, up to max journeys.
+//
+// As there is always a next page of journeys, each one being later than the previous one, max must be positive.
+func (jr *JourneyResults) All(ctx context.Context, max int) ([]types.Journey, error) {
+	if max <= 0 {
+		return nil, errors.Errorf("invalid maximum number of journeys %d, as there is always a next page it must be positive", max)
+	}
+	var all []types.Journey
+	err := collect(ctx, jr, max, func(page pager) int {
+		all = append(all, page.(*JourneyResults).Journeys...)
+		return len(all)
+	})
+	return all[:limit(len(all), max)], err
+}
+
+func (cr *ConnectionsResults) paging() (Paging, *Session) { return cr.Paging, cr.session }
+func (cr *ConnectionsResults) newPage() pager             { return &ConnectionsResults{session: cr.session} }
+
+// NextPage requests the next page of connections, returning nil if there is none
+func (cr *ConnectionsResults) NextPage(ctx context.Context) (*ConnectionsResults, error) {
+	next, err := nextPage(ctx, cr)
+	res, _ := next.(*ConnectionsResults)
+	return res, err
+}
+
+// Pages returns an iterator through this page of connections and the following ones, each one being a *ConnectionsResults
+func (cr *ConnectionsResults) Pages(ctx context.Context) *Pages { return newPages(ctx, cr) }
+
+// All returns the connections of this page and the following ones, up to max connections if max isn't 0
+// The disruptions of each page are available through Pages.
+func (cr *ConnectionsResults) All(ctx context.Context, max int) ([]Connection, error) {
+	var all []Connection
+	err := collect(ctx, cr, max, func(page pager) int {
+		all = append(all, page.(*ConnectionsResults).Connections...)
+		return len(all)
+	})
+	return all[:limit(len(all), max)], err
+}
+
+func (ssr *StopSchedulesResults) paging() (Paging, *Session) { return ssr.Paging, ssr.session }
+func (ssr *StopSchedulesResults) newPage() pager             { return &StopSchedulesResults{session: ssr.session} }
+
+// NextPage requests the next page of stop schedules, returning nil if there is none
+func (ssr *StopSchedulesResults) NextPage(ctx context.Context) (*StopSchedulesResults, error) {
+	next, err := nextPage(ctx, ssr)
+	res, _ := next.(*StopSchedulesResults)
+	return res, err
+}
+
+// Pages returns an iterator through this page of stop schedules and the following ones, each one being a *StopSchedulesResults
+func (ssr *StopSchedulesResults) Pages(ctx context.Context) *Pages { return newPages(ctx, ssr) }
+
+// All returns the stop schedules of this page and the following ones, up to max stop schedules if max isn't 0
+func (ssr *StopSchedulesResults) All(ctx context.Context, max int) ([]types.StopSchedule, error) {
+	var all []types.StopSchedule
+	err := collect(ctx, ssr, max, func(page pager) int {
+		all = append(all, page.(*StopSchedulesResults).StopSchedules...)
+		return len(all)
+	})
+	return all[:limit(len(all), max)], err
+}
+
+func (rsr *RouteSchedulesResults) paging() (Paging, *Session) { return rsr.Paging, rsr.session }
+func (rsr *RouteSchedulesResults) newPage() pager {
+	return &RouteSchedulesResults{session: rsr.session}
+}
+
+// NextPage requests the next page of route schedules, returning nil if there is none
+func (rsr *RouteSchedulesResults) NextPage(ctx context.Context) (*RouteSchedulesResults, error) {
+	next, err := nextPage(ctx, rsr)
+	res, _ := next.(*RouteSchedulesResults)
+	return res, err
+}
+
+// Pages returns an iterator through this page of route schedules and the following ones, each one being a *RouteSchedulesResults
+func (rsr *RouteSchedulesResults) Pages(ctx context.Context) *Pages { return newPages(ctx, rsr) }
+
+// All returns the route schedules of this page and the following ones, up to max route schedules if max isn't 0
+func (rsr *RouteSchedulesResults) All(ctx context.Context, max int) ([]types.RouteSchedule, error) {
+	var all []types.RouteSchedule
+	err := collect(ctx, rsr, max, func(page pager) int {
+		all = append(all, page.(*RouteSchedulesResults).RouteSchedules...)
+		return len(all)
+	})
+	return all[:limit(len(all), max)], err
+}
+
+func (trr *TrafficReportsResults) paging() (Paging, *Session) { return trr.Paging, trr.session }
+func (trr *TrafficReportsResults) newPage() pager {
+	return &TrafficReportsResults{session: trr.session}
+}
+
+// NextPage requests the next page of traffic reports, returning nil if there is none
+func (trr *TrafficReportsResults) NextPage(ctx context.Context) (*TrafficReportsResults, error) {
+	next, err := nextPage(ctx, trr)
+	res, _ := next.(*TrafficReportsResults)
+	return res, err
+}
+
+// Pages returns an iterator through this page of traffic reports and the following ones, each one being a *TrafficReportsResults
+func (trr *TrafficReportsResults) Pages(ctx context.Context) *Pages { return newPages(ctx, trr) }
+
+// All returns the traffic reports of this page and the following ones, up to max traffic reports if max isn't 0
+// The disruptions of each page are available through Pages.
+func (trr *TrafficReportsResults) All(ctx context.Context, max int) ([]types.TrafficReport, error) {
+	var all []types.TrafficReport
+	err := collect(ctx, trr, max, func(page pager) int {
+		all = append(all, page.(*TrafficReportsResults).TrafficReports...)
+		return len(all)
+	})
+	return all[:limit(len(all), max)], err
+}
+
+func (lrr *LineReportsResults) paging() (Paging, *Session) { return lrr.Paging, lrr.session }
+func (lrr *LineReportsResults) newPage() pager             { return &LineReportsResults{session: lrr.session} }
+
+// NextPage requests the next page of line reports, returning nil if there is none
+func (lrr *LineReportsResults) NextPage(ctx context.Context) (*LineReportsResults, error) {
+	next, err := nextPage(ctx, lrr)
+	res, _ := next.(*LineReportsResults)
+	return res, err
+}
+
+// Pages returns an iterator through this page of line reports and the following ones, each one being a *LineReportsResults
+func (lrr *LineReportsResults) Pages(ctx context.Context) *Pages { return newPages(ctx, lrr) }
+
+// All returns the line reports of this page and the following ones, up to max line reports if max isn't 0
+// The disruptions of each page are available through Pages.
+func (lrr *LineReportsResults) All(ctx context.Context, max int) ([]types.LineReport, error) {
+	var all []types.LineReport
+	err := collect(ctx, lrr, max, func(page pager) int {
+		all = append(all, page.(*LineReportsResults).LineReports...)
+		return len(all)
+	})
+	return all[:limit(len(all), max)], err
+}
+
+func (pnr *PlacesNearbyResults) paging() (Paging, *Session) { return pnr.Paging, pnr.session }
+func (pnr *PlacesNearbyResults) newPage() pager             { return &PlacesNearbyResults{session: pnr.session} }
+
+// NextPage requests the next page of places, returning nil if there is none
+func (pnr *PlacesNearbyResults) NextPage(ctx context.Context) (*PlacesNearbyResults, error) {
+	next, err := nextPage(ctx, pnr)
+	res, _ := next.(*PlacesNearbyResults)
+	return res, err
+}
+
+// Pages returns an iterator through this page of places and the following ones, each one being a *PlacesNearbyResults
+func (pnr *PlacesNearbyResults) Pages(ctx context.Context) *Pages { return newPages(ctx, pnr) }
+
+// All returns the places of this page and the following ones, up to max places if max isn't 0
+func (pnr *PlacesNearbyResults) All(ctx context.Context, max int) ([]types.Container, error) {
+	var all []types.Container
+	err := collect(ctx, pnr, max, func(page pager) int {
+		all = append(all, page.(*PlacesNearbyResults).Places...)
+		return len(all)
+	})
+	return all[:limit(len(all), max)], err
+}
+
+func (lr *LinesResults) paging() (Paging, *Session) { return lr.Paging, lr.session }
+func (lr *LinesResults) newPage() pager             { return &LinesResults{session: lr.session} }
+
+// NextPage requests the next page of lines, returning nil if there is none
+func (lr *LinesResults) NextPage(ctx context.Context) (*LinesResults, error) {
+	next, err := nextPage(ctx, lr)
+	res, _ := next.(*LinesResults)
+	return res, err
+}
+
+// Pages returns an iterator through this page of lines and the following ones, each one being a *LinesResults
+func (lr *LinesResults) Pages(ctx context.Context) *Pages { return newPages(ctx, lr) }
+
+// All returns the lines of this page and the following ones, up to max lines if max isn't 0
+func (lr *LinesResults) All(ctx context.Context, max int) ([]types.Line, error) {
+	var all []types.Line
+	err := collect(ctx, lr, max, func(page pager) int {
+		all = append(all, page.(*LinesResults).Lines...)
+		return len(all)
+	})
+	return all[:limit(len(all), max)], err
+}
+
+func (rr *RoutesResults) paging() (Paging, *Session) { return rr.Paging, rr.session }
+func (rr *RoutesResults) newPage() pager             { return &RoutesResults{session: rr.session} }
+
+// NextPage requests the next page of routes, returning nil if there is none
+func (rr *RoutesResults) NextPage(ctx context.Context) (*RoutesResults, error) {
+	next, err := nextPage(ctx, rr)
+	res, _ := next.(*RoutesResults)
+	return res, err
+}
+
+// Pages returns an iterator through this page of routes and the following ones, each one being a *RoutesResults
+func (rr *RoutesResults) Pages(ctx context.Context) *Pages { return newPages(ctx, rr) }
+
+// All returns the routes of this page and the following ones, up to max routes if max isn't 0
+func (rr *RoutesResults) All(ctx context.Context, max int) ([]types.Route, error) {
+	var all []types.Route
+	err := collect(ctx, rr, max, func(page pager) int {
+		all = append(all, page.(*RoutesResults).Routes...)
+		return len(all)
+	})
+	return all[:limit(len(all), max)], err
+}
+
+func (sar *StopAreasResults) paging() (Paging, *Session) { return sar.Paging, sar.session }
+func (sar *StopAreasResults) newPage() pager             { return &StopAreasResults{session: sar.session} }
+
+// NextPage requests the next page of stop areas, returning nil if there is none
+func (sar *StopAreasResults) NextPage(ctx context.Context) (*StopAreasResults, error) {
+	next, err := nextPage(ctx, sar)
+	res, _ := next.(*StopAreasResults)
+	return res, err
+}
+
+// Pages returns an iterator through this page of stop areas and the following ones, each one being a *StopAreasResults
+func (sar *StopAreasResults) Pages(ctx context.Context) *Pages { return newPages(ctx, sar) }
+
+// All returns the stop areas of this page and the following ones, up to max stop areas if max isn't 0
+func (sar *StopAreasResults) All(ctx context.Context, max int) ([]types.StopArea, error) {
+	var all []types.StopArea
+	err := collect(ctx, sar, max, func(page pager) int {
+		all = append(all, page.(*StopAreasResults).StopAreas...)
+		return len(all)
+	})
+	return all[:limit(len(all), max)], err
+}
+
+func (spr *StopPointsResults) paging() (Paging, *Session) { return spr.Paging, spr.session }
+func (spr *StopPointsResults) newPage() pager             { return &StopPointsResults{session: spr.session} }
+
+// NextPage requests the next page of stop points, returning nil if there is none
+func (spr *StopPointsResults) NextPage(ctx context.Context) (*StopPointsResults, error) {
+	next, err := nextPage(ctx, spr)
+	res, _ := next.(*StopPointsResults)
+	return res, err
+}
+
+// Pages returns an iterator through this page of stop points and the following ones, each one being a *StopPointsResults
+func (spr *StopPointsResults) Pages(ctx context.Context) *Pages { return newPages(ctx, spr) }
+
+// All returns the stop points of this page and the following ones, up to max stop points if max isn't 0
+func (spr *StopPointsResults) All(ctx context.Context, max int) ([]types.StopPoint, error) {
+	var all []types.StopPoint
+	err := collect(ctx, spr, max, func(page pager) int {
+		all = append(all, page.(*StopPointsResults).StopPoints...)
+		return len(all)
+	})
+	return all[:limit(len(all), max)], err
+}
+
+func (nr *NetworksResults) paging() (Paging, *Session) { return nr.Paging, nr.session }
+func (nr *NetworksResults) newPage() pager             { return &NetworksResults{session: nr.session} }
+
+// NextPage requests the next page of networks, returning nil if there is none
+func (nr *NetworksResults) NextPage(ctx context.Context) (*NetworksResults, error) {
+	next, err := nextPage(ctx, nr)
+	res, _ := next.(*NetworksResults)
+	return res, err
+}
+
+// Pages returns an iterator through this page of networks and the following ones, each one being a *NetworksResults
+func (nr *NetworksResults) Pages(ctx context.Context) *Pages { return newPages(ctx, nr) }
+
+// All returns the networks of this page and the following ones, up to max networks if max isn't 0
+func (nr *NetworksResults) All(ctx context.Context, max int) ([]types.Network, error) {
+	var all []types.Network
+	err := collect(ctx, nr, max, func(page pager) int {
+		all = append(all, page.(*NetworksResults).Networks...)
+		return len(all)
+	})
+	return all[:limit(len(all), max)], err
+}
+
+func (cr *CompaniesResults) paging() (Paging, *Session) { return cr.Paging, cr.session }
+func (cr *CompaniesResults) newPage() pager             { return &CompaniesResults{session: cr.session} }
+
+// NextPage requests the next page of companies, returning nil if there is none
+func (cr *CompaniesResults) NextPage(ctx context.Context) (*CompaniesResults, error) {
+	next, err := nextPage(ctx, cr)
+	res, _ := next.(*CompaniesResults)
+	return res, err
+}
+
+// Pages returns an iterator through this page of companies and the following ones, each one being a *CompaniesResults
+func (cr *CompaniesResults) Pages(ctx context.Context) *Pages { return newPages(ctx, cr) }
+
+// All returns the companies of this page and the following ones, up to max companies if max isn't 0
+func (cr *CompaniesResults) All(ctx context.Context, max int) ([]types.Company, error) {
+	var all []types.Company
+	err := collect(ctx, cr, max, func(page pager) int {
+		all = append(all, page.(*CompaniesResults).Companies...)
+		return len(all)
+	})
+	return all[:limit(len(all), max)], err
+}
+
+func (pmr *PhysicalModesResults) paging() (Paging, *Session) { return pmr.Paging, pmr.session }
+func (pmr *PhysicalModesResults) newPage() pager             { return &PhysicalModesResults{session: pmr.session} }
+
+// NextPage requests the next page of physical modes, returning nil if there is none
+func (pmr *PhysicalModesResults) NextPage(ctx context.Context) (*PhysicalModesResults, error) {
+	next, err := nextPage(ctx, pmr)
+	res, _ := next.(*PhysicalModesResults)
+	return res, err
+}
+
+// Pages returns an iterator through this page of physical modes and the following ones, each one being a *PhysicalModesResults
+func (pmr *PhysicalModesResults) Pages(ctx context.Context) *Pages { return newPages(ctx, pmr) }
+
+// All returns the physical modes of this page and the following ones, up to max physical modes if max isn't 0
+func (pmr *PhysicalModesResults) All(ctx context.Context, max int) ([]types.PhysicalMode, error) {
+	var all []types.PhysicalMode
+	err := collect(ctx, pmr, max, func(page pager) int {
+		all = append(all, page.(*PhysicalModesResults).PhysicalModes...)
+		return len(all)
+	})
+	return all[:limit(len(all), max)], err
+}
+
+func (cmr *CommercialModesResults) paging() (Paging, *Session) { return cmr.Paging, cmr.session }
+func (cmr *CommercialModesResults) newPage() pager {
+	return &CommercialModesResults{session: cmr.session}
+}
+
+// NextPage requests the next page of commercial modes, returning nil if there is none
+func (cmr *CommercialModesResults) NextPage(ctx context.Context) (*CommercialModesResults, error) {
+	next, err := nextPage(ctx, cmr)
+	res, _ := next.(*CommercialModesResults)
+	return res, err
+}
+
+// Pages returns an iterator through this page of commercial modes and the following ones, each one being a *CommercialModesResults
+func (cmr *CommercialModesResults) Pages(ctx context.Context) *Pages { return newPages(ctx, cmr) }
+
+// All returns the commercial modes of this page and the following ones, up to max commercial modes if max isn't 0
+func (cmr *CommercialModesResults) All(ctx context.Context, max int) ([]types.CommercialMode, error) {
+	var all []types.CommercialMode
+	err := collect(ctx, cmr, max, func(page pager) int {
+		all = append(all, page.(*CommercialModesResults).CommercialModes...)
+		return len(all)
+	})
+	return all[:limit(len(all), max)], err
+}
+
+func (vjr *VehicleJourneysResults) paging() (Paging, *Session) { return vjr.Paging, vjr.session }
+func (vjr *VehicleJourneysResults) newPage() pager {
+	return &VehicleJourneysResults{session: vjr.session}
+}
+
+// NextPage requests the next page of vehicle journeys, returning nil if there is none
+func (vjr *VehicleJourneysResults) NextPage(ctx context.Context) (*VehicleJourneysResults, error) {
+	next, err := nextPage(ctx, vjr)
+	res, _ := next.(*VehicleJourneysResults)
+	return res, err
+}
+
+// Pages returns an iterator through this page of vehicle journeys and the following ones, each one being a *VehicleJourneysResults
+func (vjr *VehicleJourneysResults) Pages(ctx context.Context) *Pages { return newPages(ctx, vjr) }
+
+// All returns the vehicle journeys of this page and the following ones, up to max vehicle journeys if max isn't 0
+func (vjr *VehicleJourneysResults) All(ctx context.Context, max int) ([]types.VehicleJourney, error) {
+	var all []types.VehicleJourney
+	err := collect(ctx, vjr, max, func(page pager) int {
+		all = append(all, page.(*VehicleJourneysResults).VehicleJourneys...)
+		return len(all)
+	})
+	return all[:limit(len(all), max)], err
+}
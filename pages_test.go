@@ -0,0 +1,254 @@
+package navitia
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+// pagedServer serves the lines of a region in pages of two lines
+type pagedServer struct {
+	url   string
+	lines int
+
+	requests int32
+}
+
+func (srv *pagedServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	atomic.AddInt32(&srv.requests, 1)
+	page, _ := strconv.Atoi(r.URL.Query().Get("start_page"))
+	const perPage = 2
+
+	// Build the lines
+	var lines []string
+	for i := page * perPage; i < (page+1)*perPage && i < srv.lines; i++ {
+		lines = append(lines, fmt.Sprintf(`{"id": "line:%d"}`, i))
+	}
+	var raw []json.RawMessage
+	for _, l := range lines {
+		raw = append(raw, json.RawMessage(l))
+	}
+	linesJSON, _ := json.Marshal(raw)
+
+	// Build the links
+	links := "[]"
+	if (page+1)*perPage < srv.lines {
+		links = fmt.Sprintf(`[{"type": "next", "href": "%s/coverage/fr-idf/lines?start_page=%d", "templated": false}]`, srv.url, page+1)
+	}
+	fmt.Fprintf(w, `{"lines": %s, "links": %s, "pagination": {"start_page": %d, "items_on_page": %d, "items_per_page": %d, "total_result": %d}}`, linesJSON, links, page, len(lines), perPage, srv.lines)
+}
+
+// newPagedSession returns a session requesting a pagedServer serving the given number of lines
+func newPagedSession(t *testing.T, lines int) (*Session, *pagedServer, func()) {
+	srv := &pagedServer{lines: lines}
+	ts := httptest.NewServer(srv)
+	srv.url = ts.URL
+	session, err := NewSession("", WithBaseURL(ts.URL))
+	if err != nil {
+		ts.Close()
+		t.Fatalf("error while creating session: %v", err)
+	}
+	return session, srv, ts.Close
+}
+
+func Test_Pages(t *testing.T) {
+	session, _, done := newPagedSession(t, 5)
+	defer done()
+
+	ctx := context.Background()
+	res, err := session.Scope("fr-idf").Lines(ctx, PTRefRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Pagination.TotalResults != 5 || res.Pagination.Pages() != 3 {
+		t.Errorf("unexpected pagination %+v", res.Pagination)
+	}
+
+	// Iterate
+	var (
+		pages  int
+		starts []int
+	)
+	it := res.Pages(ctx)
+	for it.Next() {
+		pages++
+		starts = append(starts, it.Page().(*LinesResults).Pagination.StartPage)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pages != 3 || starts[0] != 0 || starts[2] != 2 {
+		t.Errorf("expected 3 pages starting at 0, 1 & 2, got %v", starts)
+	}
+
+	// The last page has no next page
+	last := it.Page().(*LinesResults)
+	if next, err := last.NextPage(ctx); next != nil || err != nil {
+		t.Errorf("expected no next page, got %v (error: %v)", next, err)
+	}
+}
+
+func Test_All(t *testing.T) {
+	session, srv, done := newPagedSession(t, 5)
+	defer done()
+
+	ctx := context.Background()
+	res, err := session.Scope("fr-idf").Lines(ctx, PTRefRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// All of them
+	lines, err := res.All(ctx, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(lines) != 5 || lines[4].ID != "line:4" {
+		t.Errorf("expected 5 lines, got %v", lines)
+	}
+
+	// Capped, the last page shouldn't be requested
+	atomic.StoreInt32(&srv.requests, 0)
+	lines, err = res.All(ctx, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(lines) != 3 {
+		t.Errorf("expected 3 lines, got %d", len(lines))
+	}
+	if got := atomic.LoadInt32(&srv.requests); got != 1 {
+		t.Errorf("expected 1 request, got %d", got)
+	}
+}
+
+func Test_Pages_cancelled(t *testing.T) {
+	session, srv, done := newPagedSession(t, 10)
+	defer done()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	res, err := session.Scope("fr-idf").Lines(ctx, PTRefRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	it := res.Pages(ctx)
+	it.Next()
+	it.Next()
+	cancel()
+	if it.Next() {
+		t.Errorf("expected the iteration to stop once cancelled")
+	}
+	if it.Err() != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", it.Err())
+	}
+	if got := atomic.LoadInt32(&srv.requests); got != 2 {
+		t.Errorf("expected 2 requests, got %d", got)
+	}
+}
+
+// Test_ConnectionsResults_NextPage checks that connections, which used not to hold their session, can be paginated
+func Test_ConnectionsResults_NextPage(t *testing.T) {
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("start_page") == "" {
+			fmt.Fprintf(w, `{"departures": [], "links": [{"type": "next", "href": "%s/coverage/fr-idf/stop_areas/sa/departures?start_page=1"}]}`, ts.URL)
+			return
+		}
+		fmt.Fprint(w, `{"departures": [], "pagination": {"start_page": 1}}`)
+	}))
+	defer ts.Close()
+
+	session, err := NewSession("", WithBaseURL(ts.URL))
+	if err != nil {
+		t.Fatalf("error while creating session: %v", err)
+	}
+	ctx := context.Background()
+	res, err := session.Scope("fr-idf").DeparturesSA(ctx, ConnectionsRequest{}, "sa")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	next, err := res.NextPage(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if next == nil || next.Pagination.StartPage != 1 {
+		t.Errorf("expected the second page, got %+v", next)
+	}
+}
+
+// Test_JourneyResults_All checks that collecting journeys, whose next pages never end, requires a maximum and stops on a repeated page
+func Test_JourneyResults_All(t *testing.T) {
+	var (
+		ts       *httptest.Server
+		requests int32
+		page     int32
+		repeat   int32
+	)
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		n := atomic.AddInt32(&page, 1)
+		if atomic.LoadInt32(&repeat) != 0 {
+			n = 0
+		}
+		fmt.Fprintf(w, `{"journeys": [{}, {}], "links": [{"type": "next", "href": "%s/journeys?after=%d"}]}`, ts.URL, n)
+	}))
+	defer ts.Close()
+
+	session, err := NewSession("", WithBaseURL(ts.URL))
+	if err != nil {
+		t.Fatalf("error while creating session: %v", err)
+	}
+	ctx := context.Background()
+	res, err := session.Journeys(ctx, JourneyRequest{From: "a", To: "b"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Without a maximum
+	if _, err := res.All(ctx, 0); err == nil {
+		t.Errorf("expected an error without a maximum")
+	}
+
+	// With one
+	atomic.StoreInt32(&requests, 0)
+	journeys, err := res.All(ctx, 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(journeys) != 5 || requests != 2 {
+		t.Errorf("expected 5 journeys in 2 requests, got %d in %d", len(journeys), requests)
+	}
+
+	// A repeated page stops the iteration
+	atomic.StoreInt32(&repeat, 1)
+	first, err := session.Journeys(ctx, JourneyRequest{From: "a", To: "b"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	journeys, err = first.All(ctx, 100)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(journeys) != 2 {
+		t.Errorf("expected the journeys of the first page only, got %d", len(journeys))
+	}
+}
+
+// Test_Pagination_Unmarshal checks that the pagination of the lines test data is unmarshalled
+func Test_Pagination_Unmarshal(t *testing.T) {
+	for name, b := range testData["lines"].correct {
+		var res LinesResults
+		if err := json.Unmarshal(b, &res); err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		if res.Pagination.TotalResults == 0 || res.Pagination.ItemsPerPage == 0 {
+			t.Errorf("%s: expected the pagination to be unmarshalled, got %+v", name, res.Pagination)
+		}
+	}
+}
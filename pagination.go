@@ -0,0 +1,134 @@
+package navitia
+
+import "context"
+
+// Pagination describes the position of a page of results, as reported by the "pagination" object of the response
+type Pagination struct {
+	// StartPage is the index of the page, starting at 0
+	StartPage int `json:"start_page"`
+
+	// ItemsOnPage is the number of items on the page
+	ItemsOnPage int `json:"items_on_page"`
+
+	// ItemsPerPage is the maximum number of items on a page
+	ItemsPerPage int `json:"items_per_page"`
+
+	// TotalResults is the total number of items, across all the pages
+	TotalResults int `json:"total_result"`
+}
+
+// Pages returns the total number of pages, or 0 if it is unknown
+func (p Pagination) Pages() int {
+	if p.ItemsPerPage <= 0 {
+		return 0
+	}
+	return (p.TotalResults + p.ItemsPerPage - 1) / p.ItemsPerPage
+}
+
+// A pager is a page of results, which may be followed by others
+type pager interface {
+	results
+
+	// paging returns the paging of the page, and the session to request the following ones with
+	paging() (Paging, *Session)
+
+	// newPage returns an empty page of the same type, to decode the next one in
+	newPage() pager
+}
+
+// nextPage requests the page following the given one, returning nil if there is none
+func nextPage(ctx context.Context, page pager) (pager, error) {
+	paging, s := page.paging()
+	if paging.Next == "" || s == nil {
+		return nil, nil
+	}
+	next := page.newPage()
+	err := s.requestCursor(ctx, paging.Next, next)
+	if err != nil {
+		return nil, err
+	}
+	return next, nil
+}
+
+// A Page is a page of results which may be followed by others, such as *LinesResults
+type Page interface {
+	pager
+}
+
+// Pages iterates lazily through pages of results, each one of the type of the first one, see for example LinesResults.Pages
+//
+// The iteration is lazy: each page is only requested when moving to it, and it stops at the first error, including the cancellation of the context.
+// It also stops if a page links to the same next page as the previous one, as navitia would be repeating itself.
+type Pages struct {
+	ctx     context.Context
+	page    pager
+	started bool
+	done    bool
+	err     error
+}
+
+// newPages returns an iterator starting at the given page
+func newPages(ctx context.Context, page pager) *Pages {
+	return &Pages{ctx: ctx, page: page}
+}
+
+// Next moves to the next page, returning false when there are no more pages or if an error occurred, see Err
+func (p *Pages) Next() bool {
+	if p.done {
+		return false
+	}
+	if !p.started {
+		p.started = true
+		return true
+	}
+	if err := p.ctx.Err(); err != nil {
+		p.err = err
+		p.done = true
+		return false
+	}
+	next, err := nextPage(p.ctx, p.page)
+	if err != nil || next == nil || repeats(p.page, next) {
+		p.err = err
+		p.done = true
+		return false
+	}
+	p.page = next
+	return true
+}
+
+// Page returns the current page, to be asserted to the type of the first one, such as *LinesResults
+func (p *Pages) Page() Page {
+	return p.page
+}
+
+// Err returns the error which stopped the iteration, if any
+func (p *Pages) Err() error {
+	return p.err
+}
+
+// repeats returns true if the next page links to the same page as the previous one
+func repeats(previous, next pager) bool {
+	a, _ := previous.paging()
+	b, _ := next.paging()
+	return a.Next != "" && a.Next == b.Next
+}
+
+// collect iterates through the given page and the following ones, calling add with each one until it returns at least max items, if max isn't 0.
+// add returns the number of items collected so far.
+func collect(ctx context.Context, page pager, max int, add func(page pager) int) error {
+	it := newPages(ctx, page)
+	for it.Next() {
+		if n := add(it.page); max != 0 && n >= max {
+			return nil
+		}
+	}
+	return it.Err()
+}
+
+// limit returns n, capped to max if it isn't 0
+func limit(n int, max int) int {
+	if max != 0 && n > max {
+		return max
+	}
+	return n
+}
@@ -2,27 +2,86 @@ package navitia
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"net/url"
+	"strings"
 
+	"github.com/aabizri/navitia/types"
 	"github.com/pkg/errors"
 )
 
-// Paging holds potential Previous / Next functions
+// Paging holds the cursors to the neighbouring pages of results, which are empty if there are no such pages
+//
+// For journeys, the pages are shifted in time: the previous & next ones hold earlier & later journeys, while the first & last ones hold the first & last journeys of the day.
 type Paging struct {
 	// Next results
-	Next func(ctx context.Context, s *Session, res results) error
+	Next Cursor
 
 	// Previous results
-	Previous func(ctx context.Context, s *Session, res results) error
+	Previous Cursor
+
+	// First & Last pages of results
+	First Cursor
+	Last  Cursor
 }
 
-// createPagingFunc creates a paging func (either Previous or Next)
-func createPagingFunc(url string) func(ctx context.Context, s *Session, res results) error {
-	f := func(ctx context.Context, s *Session, res results) error {
-		err := s.requestURL(ctx, url, res)
-		return err
+// A Cursor is an opaque position in paginated results, see Paging.
+//
+// As a string, it can be persisted or handed to a browser, then resumed with Session.ResumeJourneys or Scope.ResumeConnections.
+type Cursor string
+
+// ErrInvalidCursor is returned when resuming from a cursor which is malformed, or which doesn't point to the API of the session or to the expected endpoint
+var ErrInvalidCursor = errors.New("navitia: invalid cursor")
+
+// newCursor creates a cursor given the href of a link
+func newCursor(href string) Cursor {
+	return Cursor(base64.RawURLEncoding.EncodeToString([]byte(href)))
+}
+
+// href returns the href of the link a cursor was created from
+func (c Cursor) href() (string, error) {
+	b, err := base64.RawURLEncoding.DecodeString(string(c))
+	if err != nil {
+		return "", errors.Wrap(ErrInvalidCursor, "malformed cursor")
+	}
+	return string(b), nil
+}
+
+// cursorURL returns the url a cursor points to, checking that it is one of the session's API, and that its endpoint is one of the given ones if there are any
+//
+// It is used for the cursors given by the user, the ones of the results being followed as-is.
+func (s *Session) cursorURL(cursor Cursor, endpoints ...string) (string, error) {
+	href, err := cursor.href()
+	if err != nil {
+		return "", err
+	}
+
+	// Check that it points to the API
+	u, err := url.Parse(href)
+	if err != nil {
+		return "", errors.Wrap(ErrInvalidCursor, "malformed cursor URL")
 	}
-	return f
+	api, err := url.Parse(s.APIURL)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid API URL %q", s.APIURL)
+	}
+	base := strings.TrimSuffix(api.Path, "/")
+	if u.Scheme != api.Scheme || u.Host != api.Host || u.User != nil || (u.Path != base && !strings.HasPrefix(u.Path, base+"/")) {
+		return "", errors.Wrapf(ErrInvalidCursor, "cursor doesn't point to the API (%s)", s.APIURL)
+	}
+
+	// Check its endpoint
+	if len(endpoints) == 0 {
+		return href, nil
+	}
+	endpoint := endpointOf(href)
+	for _, expected := range endpoints {
+		if endpoint == expected {
+			return href, nil
+		}
+	}
+	return "", errors.Wrapf(ErrInvalidCursor, "cursor points to the %q endpoint, expected one of %v", endpoint, endpoints)
 }
 
 // UnmarshalJSON unmarshals a Paging type from a Links data structure
@@ -33,13 +92,20 @@ func (p *Paging) UnmarshalJSON(b []byte) error {
 		return errors.Wrap(err, "error while unmarshalling links")
 	}
 
-	// Iterate through the links
+	// Iterate through the links, templated ones can't be followed
 	for _, l := range links {
+		if l.Templated {
+			continue
+		}
 		switch l.Type {
 		case "next":
-			p.Next = createPagingFunc(l.Href)
-		case "previous":
-			p.Previous = createPagingFunc(l.Href)
+			p.Next = newCursor(l.Href)
+		case "previous", "prev":
+			p.Previous = newCursor(l.Href)
+		case "first":
+			p.First = newCursor(l.Href)
+		case "last":
+			p.Last = newCursor(l.Href)
 		}
 	}
 
@@ -52,4 +118,20 @@ type link struct {
 	Rel       string
 	Templated bool
 	Type      string
+
+	// Internal links reference an object elsewhere in the response, such as a disruption
+	ID       types.ID
+	Internal bool
+}
+
+// requestCursor requests the page a cursor of the results points to, decoding it in res
+//
+// As the links are provided by the server, they are followed as-is, even if it is behind a proxy answering with links to another URL.
+// The API key is then only sent if the link points to the session's API.
+func (s *Session) requestCursor(ctx context.Context, cursor Cursor, res results) error {
+	url, err := cursor.href()
+	if err != nil {
+		return err
+	}
+	return s.requestURL(ctx, url, res)
 }
@@ -0,0 +1,173 @@
+package navitia
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func Test_Paging_UnmarshalJSON(t *testing.T) {
+	// Declare this test to be run in parallel
+	t.Parallel()
+
+	const data = `[
+		{"type": "next", "href": "https://api.navitia.io/v1/journeys?next"},
+		{"type": "previous", "href": "https://api.navitia.io/v1/journeys?previous"},
+		{"type": "first", "href": "https://api.navitia.io/v1/journeys?first"},
+		{"type": "last", "href": "https://api.navitia.io/v1/journeys?{last}", "templated": true},
+		{"type": "disruption", "id": "d1", "internal": true}
+	]`
+	var p Paging
+	if err := json.Unmarshal([]byte(data), &p); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	session := &Session{APIURL: "https://api.navitia.io/v1"}
+	for name, cursor := range map[string]Cursor{"next": p.Next, "previous": p.Previous, "first": p.First} {
+		url, err := session.cursorURL(cursor)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+		} else if expected := "https://api.navitia.io/v1/journeys?" + name; url != expected {
+			t.Errorf("%s: expected %s, got %s", name, expected, url)
+		}
+	}
+	if p.Last != "" {
+		t.Errorf("expected the templated link to be ignored, got %s", p.Last)
+	}
+}
+
+func Test_Session_cursorURL(t *testing.T) {
+	// Declare this test to be run in parallel
+	t.Parallel()
+
+	session := &Session{APIURL: "https://api.navitia.io/v1"}
+	tests := []struct {
+		cursor    Cursor
+		endpoints []string
+		valid     bool
+	}{
+		{newCursor("https://api.navitia.io/v1/journeys?from=a"), nil, true},
+		{newCursor("https://api.navitia.io/v1/journeys?from=a"), []string{journeysEndpoint}, true},
+		{newCursor("https://api.navitia.io/v1/coverage/fr-idf/stop_areas/sa/departures"), []string{departuresEndpoint, arrivalsEndpoint}, true},
+		{newCursor("https://api.navitia.io/v1/coverage/fr-idf/lines"), []string{journeysEndpoint}, false},
+		{newCursor("https://evil.example.com/v1/journeys"), nil, false},
+		{newCursor("http://api.navitia.io/v1/journeys"), nil, false},
+		{newCursor("https://user@api.navitia.io/v1/journeys"), nil, false},
+		{newCursor("https://api.navitia.io/v10/journeys"), nil, false},
+		{"not base64!", nil, false},
+	}
+
+	for i, test := range tests {
+		_, err := session.cursorURL(test.cursor, test.endpoints...)
+		if test.valid && err != nil {
+			t.Errorf("case #%d: unexpected error: %v", i, err)
+		}
+		if !test.valid && !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("case #%d: expected ErrInvalidCursor, got %v", i, err)
+		}
+	}
+}
+
+// Test_Resume checks that a persisted cursor can be resumed by a new session
+func Test_Resume(t *testing.T) {
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next := fmt.Sprintf(`[{"type": "next", "href": "%s%s?page=%s"}]`, ts.URL, r.URL.Path, r.URL.Query().Get("page")+"x")
+		switch endpointOf(r.URL.String()) {
+		case journeysEndpoint:
+			fmt.Fprintf(w, `{"journeys": [], "links": %s}`, next)
+		case departuresEndpoint:
+			fmt.Fprintf(w, `{"departures": [], "links": %s}`, next)
+		}
+	}))
+	defer ts.Close()
+
+	newSession := func() *Session {
+		session, err := NewSession("", WithBaseURL(ts.URL))
+		if err != nil {
+			t.Fatalf("error while creating session: %v", err)
+		}
+		return session
+	}
+	ctx := context.Background()
+
+	// Journeys
+	jr, err := newSession().Journeys(ctx, JourneyRequest{From: "a", To: "b"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cursor := string(jr.Paging.Next)
+	jr, err = newSession().ResumeJourneys(ctx, Cursor(cursor))
+	if err != nil {
+		t.Fatalf("error while resuming journeys: %v", err)
+	}
+	if jr.Paging.Next == "" {
+		t.Errorf("expected the resumed page to have a next page")
+	}
+
+	// Connections
+	scope := newSession().Scope("fr-idf")
+	cr, err := scope.DeparturesSA(ctx, ConnectionsRequest{}, "sa")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := newSession().Scope("fr-idf").ResumeConnections(ctx, cr.Paging.Next); err != nil {
+		t.Errorf("error while resuming connections: %v", err)
+	}
+
+	// Mismatches
+	if _, err := newSession().Scope("fr-nw").ResumeConnections(ctx, cr.Paging.Next); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("expected ErrInvalidCursor when resuming in another region, got %v", err)
+	}
+	if _, err := newSession().ResumeJourneys(ctx, cr.Paging.Next); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("expected ErrInvalidCursor when resuming connections as journeys, got %v", err)
+	}
+}
+
+// Test_Session_requestCursor checks that the links of the results are followed even if they don't point to the session's API, as when it is behind a proxy, but without sending the API key to another host
+func Test_Session_requestCursor(t *testing.T) {
+	var backendAuth, proxyAuth string
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		backendAuth = r.Header.Get("Authorization")
+		fmt.Fprint(w, `{"journeys": [{}]}`)
+	}))
+	defer backend.Close()
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxyAuth = r.Header.Get("Authorization")
+		fmt.Fprintf(w, `{"journeys": [], "links": [{"type": "next", "href": "%s/v1/journeys?page=2"}]}`, backend.URL)
+	}))
+	defer proxy.Close()
+
+	session, err := NewSession("key", WithBaseURL(proxy.URL+"/v1"))
+	if err != nil {
+		t.Fatalf("error while creating session: %v", err)
+	}
+	ctx := context.Background()
+	jr, err := session.Journeys(ctx, JourneyRequest{From: "a", To: "b"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	next, err := jr.NextPage(ctx)
+	if err != nil {
+		t.Fatalf("error while following the next link: %v", err)
+	}
+	if next == nil || len(next.Journeys) != 1 {
+		t.Errorf("expected the next page to be requested from the link, got %+v", next)
+	}
+	if proxyAuth == "" {
+		t.Errorf("expected the API key to be sent to the session's API")
+	}
+	if backendAuth != "" {
+		t.Errorf("expected no Authorization header to reach another host, got %q", backendAuth)
+	}
+
+	// But it can't be resumed
+	if _, err := session.ResumeJourneys(ctx, jr.Paging.Next); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("expected ErrInvalidCursor when resuming a cursor to another host, got %v", err)
+	}
+}
@@ -0,0 +1,98 @@
+package navitia
+
+import (
+	"context"
+	"sync"
+
+	"github.com/aabizri/navitia/types"
+	"github.com/pkg/errors"
+)
+
+// DefaultPlacesParallelism is the number of concurrent requests made by PlacesByID when no parallelism is given
+const DefaultPlacesParallelism uint = 4
+
+// PlaceByID requests a place or public transport object given its ID, for example from a previous search or a persisted favorite.
+//
+// The returned Container has its embedded object already decoded, so calling Object on it can't fail.
+//
+// It is context aware.
+func (scope *Scope) PlaceByID(ctx context.Context, id types.ID) (*types.Container, error) {
+	// Create the URL
+	url := scope.session.APIURL + "/coverage/" + string(scope.region) + "/" + placesEndpoint + "/" + string(id)
+
+	// Call
+	var results = &PlacesResults{session: scope.session}
+	err := scope.session.requestURL(ctx, url, results)
+	if err != nil {
+		return nil, err
+	}
+	if results.Len() == 0 {
+		return nil, errors.Errorf("no place found with ID %s", id)
+	}
+	container := &results.Places[0]
+
+	// Decode the embedded object
+	_, err = container.Object()
+	if err != nil {
+		return container, errors.Wrapf(err, "error while decoding place %s", id)
+	}
+	return container, nil
+}
+
+// PlacesByID requests many places concurrently given their IDs, with at most parallelism requests in flight.
+// If parallelism is 0, DefaultPlacesParallelism is used.
+//
+// The nth container returned is the one with the nth ID.
+// If any of the requests fail, the remaining ones are cancelled and the first error is returned.
+//
+// It is context aware.
+func (scope *Scope) PlacesByID(ctx context.Context, ids []types.ID, parallelism uint) ([]*types.Container, error) {
+	if parallelism == 0 {
+		parallelism = DefaultPlacesParallelism
+	}
+
+	// Cancel the remaining requests on the first error
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		containers = make([]*types.Container, len(ids))
+		firstErr   error
+		once       sync.Once
+		wg         sync.WaitGroup
+		sem        = make(chan struct{}, parallelism)
+	)
+
+	for i, id := range ids {
+		// Acquire a slot, unless we've been cancelled
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(i int, id types.ID) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			c, err := scope.PlaceByID(ctx, id)
+			if err != nil {
+				once.Do(func() {
+					firstErr = errors.Wrapf(err, "error while requesting place %s", id)
+					cancel()
+				})
+				return
+			}
+			containers[i] = c
+		}(i, id)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return containers, firstErr
+	}
+	return containers, ctx.Err()
+}
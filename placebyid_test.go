@@ -0,0 +1,90 @@
+package navitia
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aabizri/navitia/types"
+)
+
+// placesByIDServer serves /places/{id} requests with a stop area, failing for IDs containing "unknown", and records the maximum number of concurrent requests
+type placesByIDServer struct {
+	mu      sync.Mutex
+	current int
+	max     int
+}
+
+func (srv *placesByIDServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	srv.mu.Lock()
+	srv.current++
+	if srv.current > srv.max {
+		srv.max = srv.current
+	}
+	srv.mu.Unlock()
+	defer func() {
+		srv.mu.Lock()
+		srv.current--
+		srv.mu.Unlock()
+	}()
+
+	// Leave time for other requests to pile up
+	time.Sleep(10 * time.Millisecond)
+
+	id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+	if strings.Contains(id, "unknown") {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error": {"id": "unknown_object", "message": "Invalid id"}}`)
+		return
+	}
+	fmt.Fprintf(w, `{"places": [{"id": %q, "name": "Test", "embedded_type": "stop_area", "stop_area": {"id": %q, "name": "Test"}}]}`, id, id)
+}
+
+func Test_Scope_PlacesByID(t *testing.T) {
+	srv := &placesByIDServer{}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	session, err := NewCustom("", ts.URL, http.DefaultClient)
+	if err != nil {
+		t.Fatalf("error while creating session: %v", err)
+	}
+	scope := session.Scope("sandbox")
+	ctx := context.Background()
+
+	ids := make([]types.ID, 10)
+	for i := range ids {
+		ids[i] = types.ID(fmt.Sprintf("stop_area:TEST:%d", i))
+	}
+
+	t.Run("bounded", func(t *testing.T) {
+		containers, err := scope.PlacesByID(ctx, ids, 3)
+		if err != nil {
+			t.Fatalf("error in PlacesByID: %v", err)
+		}
+		for i, c := range containers {
+			if c == nil || c.ID != ids[i] {
+				t.Fatalf("container #%d doesn't match ID %s: %#v", i, ids[i], c)
+			}
+			obj, err := c.Object()
+			if _, ok := obj.(*types.StopArea); err != nil || !ok {
+				t.Errorf("container #%d: expected a decoded stop area, got %#v (error: %v)", i, obj, err)
+			}
+		}
+		if srv.max > 3 {
+			t.Errorf("expected at most 3 concurrent requests, got %d", srv.max)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		_, err := scope.PlacesByID(ctx, append(ids, "stop_area:unknown"), 0)
+		if err == nil {
+			t.Fatalf("expected an error for an unknown place, got none")
+		}
+	})
+}
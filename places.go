@@ -15,6 +15,9 @@ import (
 type PlacesResults struct {
 	Places []types.Container `json:"places"`
 
+	// Context holds the timezone of the region, in which the date times of the results are expressed
+	Context types.Context `json:"context"`
+
 	Logging `json:"-"`
 
 	session *Session
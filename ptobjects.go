@@ -0,0 +1,103 @@
+package navitia
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/aabizri/navitia/types"
+)
+
+// PTObjectsResults holds the results of a public transport objects search.
+// Like PlacesResults, it doesn't have pagination, as the remote API doesn't support it.
+//
+// PTObjectsResults can be sorted, it implements sort.Interface.
+type PTObjectsResults struct {
+	PTObjects []types.Container `json:"pt_objects"`
+
+	// Context holds the timezone of the region, in which the date times of the results are expressed
+	Context types.Context `json:"context"`
+
+	Logging `json:"-"`
+
+	session *Session
+}
+
+// Count returns the number of public transport objects available in a PTObjectsResults
+func (ptr *PTObjectsResults) Count() int {
+	return len(ptr.PTObjects)
+}
+
+// Len is the number of PTObjects in the results.
+func (ptr *PTObjectsResults) Len() int {
+	return len(ptr.PTObjects)
+}
+
+// Less reports if the quality of the PTObject with the index i is less than that of the PTObject with the index j
+//
+// Note: In most use cases, that's the opposite of the desired behaviour, so simply use sort.Reverse and ta-da !
+func (ptr *PTObjectsResults) Less(i, j int) bool {
+	return ptr.PTObjects[i].Quality < ptr.PTObjects[j].Quality
+}
+
+// Swap swaps the PTObject of index i and the PTObject of index j
+func (ptr *PTObjectsResults) Swap(i, j int) {
+	ptr.PTObjects[i], ptr.PTObjects[j] = ptr.PTObjects[j], ptr.PTObjects[i]
+}
+
+// PTObjectsRequest is the query you need to build before passing it to PTObjects
+type PTObjectsRequest struct {
+	Query string // The search item
+
+	// Types are the type of objects to query
+	// It can either be a network, a commercial_mode, a line, a route or a stop_area
+	Types []string
+
+	// Maximum amount of results
+	Count uint
+
+	// DisableDisruption removes the disruptions from the results
+	DisableDisruption bool
+}
+
+// toURL formats a PTObjects request to url
+func (req PTObjectsRequest) toURL() (url.Values, error) {
+	params := url.Values{
+		"q": []string{req.Query},
+	}
+
+	if len(req.Types) != 0 {
+		params["type[]"] = req.Types
+	}
+
+	if req.Count != 0 {
+		countStr := strconv.FormatUint(uint64(req.Count), 10)
+		params["count"] = []string{countStr}
+	}
+
+	if req.DisableDisruption {
+		params["disable_disruption"] = []string{"true"}
+	}
+	return params, nil
+}
+
+const ptObjectsEndpoint = "pt_objects"
+
+// PTObjects searches in all public transport objects within a coverage using their names, returning a list of corresponding objects, such as lines, routes or networks.
+//
+// It is context aware.
+func (scope *Scope) PTObjects(ctx context.Context, params PTObjectsRequest) (*PTObjectsResults, error) {
+	// Create the URL
+	url := scope.session.APIURL + "/coverage/" + string(scope.region) + "/" + ptObjectsEndpoint
+
+	// Call
+	var results = &PTObjectsResults{session: scope.session}
+	err := scope.session.request(ctx, url, params, results)
+
+	// Sort the objects if quality is defined on the results, as done for places
+	if results.Len() != 0 && results.PTObjects[0].Quality != 0 {
+		sort.Sort(sort.Reverse(results))
+	}
+	return results, err
+}
@@ -0,0 +1,77 @@
+package navitia
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/aabizri/navitia/types"
+)
+
+// Test_PTObjectsRequest_toUrl checks the parameters created by PTObjectsRequest.toURL
+func Test_PTObjectsRequest_toUrl(t *testing.T) {
+	// Declare this test to be run in parallel
+	t.Parallel()
+
+	req := PTObjectsRequest{
+		Query:             "metro 4",
+		Types:             []string{"line", "network"},
+		Count:             5,
+		DisableDisruption: true,
+	}
+	values, err := req.toURL()
+	if err != nil {
+		t.Fatalf("error in PTObjectsRequest.toURL: %v\n\tReceived: %#v", err, values)
+	}
+
+	expected := map[string][]string{
+		"q":                  {"metro 4"},
+		"type[]":             {"line", "network"},
+		"count":              {"5"},
+		"disable_disruption": {"true"},
+	}
+	if !reflect.DeepEqual(map[string][]string(values), expected) {
+		t.Errorf("error in PTObjectsRequest.toURL: expected %v, got %v", expected, values)
+	}
+}
+
+// Test_PTObjectsResults_Unmarshal tests unmarshalling for PTObjectsResults.
+// As the unmarshalling is done by encoding/json, this allows us to check that the input can be reliably unmarshalled into the structure we have for it.
+//
+// This launches both a "correct" and "incorrect" subtest, allowing us to test both cases.
+// 	If we expect no errors but we get one, the test fails
+//	If we expect an error but we don't get one, the test fails
+func Test_PTObjectsResults_Unmarshal(t *testing.T) {
+	testUnmarshal(t, testData["pt_objects"], reflect.TypeOf(PTObjectsResults{}))
+}
+
+// Test_PTObjectsResults_Sort checks that the results are sorted by quality, and that the objects are typed
+func Test_PTObjectsResults_Sort(t *testing.T) {
+	// Declare this test to be run in parallel
+	t.Parallel()
+
+	data := []byte(`{"pt_objects": [
+		{"id": "network:OIF:439", "name": "RATP", "quality": 50, "embedded_type": "network", "network": {"id": "network:OIF:439", "name": "RATP"}},
+		{"id": "line:OIF:100110004:4OIF439", "name": "4", "quality": 90, "embedded_type": "line", "line": {"id": "line:OIF:100110004:4OIF439", "code": "4"}}
+	]}`)
+	results := &PTObjectsResults{}
+	if err := json.Unmarshal(data, results); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sort.Sort(sort.Reverse(results))
+	if results.PTObjects[0].ID != "line:OIF:100110004:4OIF439" {
+		t.Errorf("expected the best object first, got %s", results.PTObjects[0].ID)
+	}
+
+	// Check the objects' types
+	line, err := results.PTObjects[0].Object()
+	if _, ok := line.(*types.Line); !ok || err != nil {
+		t.Errorf("expected a *types.Line, got %T (error: %v)", line, err)
+	}
+	network, err := results.PTObjects[1].Object()
+	if _, ok := network.(*types.Network); !ok || err != nil {
+		t.Errorf("expected a *types.Network, got %T (error: %v)", network, err)
+	}
+}
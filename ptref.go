@@ -0,0 +1,488 @@
+package navitia
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"unsafe"
+
+	"github.com/aabizri/navitia/filter"
+	"github.com/aabizri/navitia/types"
+	"github.com/pkg/errors"
+)
+
+// PTRefRequest contains the optional parameters for a public transport referential request, such as Lines or StopAreas.
+type PTRefRequest struct {
+	// Within restricts the results to the objects related to the given ones, building nested paths.
+	// For example, requesting StopAreas within a line gives /lines/{line.id}/stop_areas, the stop areas of that line.
+	//
+	// The type of each object is inferred from its ID.
+	Within []types.ID
+
+	// Depth of the objects returned, from 0 to 3: the deeper, the more nested objects are returned.
+	//
+	// If nil, the default depth (1) is used.
+	Depth *uint
+
+	// The maximum amount of objects per page
+	Count uint
+
+	// The page to retrieve
+	StartPage uint
+
+	// ForbiddenURIs
+	Forbidden []types.ID
+
+	// Filter restricts the results to the objects matching it, see the filter package
+	Filter filter.Filter
+
+	// Enables GeoJSON data in the reply. GeoJSON objects can be VERY large ! >1MB.
+	Geo bool
+}
+
+func (req PTRefRequest) toURL() (url.Values, error) {
+	values := url.Values{}
+
+	if depth := req.Depth; depth != nil {
+		if *depth > 3 {
+			return nil, errors.Errorf("invalid depth %d, it must be between 0 and 3", *depth)
+		}
+		values.Add("depth", strconv.FormatUint(uint64(*depth), 10))
+	}
+
+	if count := req.Count; count != 0 {
+		values.Add("count", strconv.FormatUint(uint64(count), 10))
+	}
+	if page := req.StartPage; page != 0 {
+		values.Add("start_page", strconv.FormatUint(uint64(page), 10))
+	}
+
+	// Deal with the forbidden URIs
+	if forbidden := req.Forbidden; len(forbidden) != 0 {
+		magic := *(*[]string)(unsafe.Pointer(&forbidden))
+		values["forbidden_uris[]"] = magic
+	}
+
+	// Add the filter
+	if f := req.Filter; !f.Empty() {
+		if err := f.Validate(); err != nil {
+			return nil, errors.Wrap(err, "invalid filter")
+		}
+		values.Add("filter", f.String())
+	}
+
+	// Add GEO
+	if !req.Geo {
+		values.Add("disable_geojson", "true")
+	}
+
+	return values, nil
+}
+
+// ptrefURL builds the URL of a public transport referential request in a scope.
+//
+// It nests the objects of req.Within, then the collection, then the id if it isn't empty: /coverage/{region}/lines/{line.id}/stop_areas/{id}
+func (scope *Scope) ptrefURL(req PTRefRequest, collection string, id types.ID) (string, error) {
+	url := scope.session.APIURL + "/coverage/" + string(scope.region)
+
+	// Nest the objects
+	for _, parent := range req.Within {
+		path, err := objectPath(parent)
+		if err != nil {
+			return "", err
+		}
+		url += path
+	}
+
+	// Add the collection and the ID
+	url += "/" + collection
+	if id != "" {
+		url += "/" + string(id)
+	}
+
+	return url, nil
+}
+
+// ptref is the internal function used by the public transport referential functions
+func (scope *Scope) ptref(ctx context.Context, req PTRefRequest, collection string, id types.ID, res results) error {
+	// Create the URL
+	url, err := scope.ptrefURL(req, collection, id)
+	if err != nil {
+		return err
+	}
+
+	// Call
+	return scope.session.request(ctx, url, req, res)
+}
+
+const (
+	linesEndpoint           string = "lines"
+	routesEndpoint          string = "routes"
+	stopAreasEndpoint       string = "stop_areas"
+	stopPointsEndpoint      string = "stop_points"
+	networksEndpoint        string = "networks"
+	companiesEndpoint       string = "companies"
+	physicalModesEndpoint   string = "physical_modes"
+	commercialModesEndpoint string = "commercial_modes"
+	vehicleJourneysEndpoint string = "vehicle_journeys"
+)
+
+// LinesResults holds the results of a lines request.
+type LinesResults struct {
+	Lines []types.Line `json:"lines"`
+
+	Paging Paging `json:"links"`
+
+	// Pagination describes the position of this page in the results
+	Pagination Pagination `json:"pagination"`
+
+	// Context holds the timezone of the region, in which the date times of the results are expressed
+	Context types.Context `json:"context"`
+
+	Logging `json:"-"`
+
+	session *Session
+}
+
+// Count returns the number of lines available in a LinesResults
+func (lr *LinesResults) Count() int {
+	return len(lr.Lines)
+}
+
+// Lines lists the lines of the scope, or the ones related to the objects given in req.Within.
+//
+// It is context aware.
+func (scope *Scope) Lines(ctx context.Context, req PTRefRequest) (*LinesResults, error) {
+	var results = &LinesResults{session: scope.session}
+	err := scope.ptref(ctx, req, linesEndpoint, "", results)
+	return results, err
+}
+
+// LineByID provides information about a specific line.
+//
+// It is context aware.
+func (scope *Scope) LineByID(ctx context.Context, req PTRefRequest, id types.ID) (*LinesResults, error) {
+	var results = &LinesResults{session: scope.session}
+	err := scope.ptref(ctx, req, linesEndpoint, id, results)
+	return results, err
+}
+
+// RoutesResults holds the results of a routes request.
+type RoutesResults struct {
+	Routes []types.Route `json:"routes"`
+
+	Paging Paging `json:"links"`
+
+	// Pagination describes the position of this page in the results
+	Pagination Pagination `json:"pagination"`
+
+	// Context holds the timezone of the region, in which the date times of the results are expressed
+	Context types.Context `json:"context"`
+
+	Logging `json:"-"`
+
+	session *Session
+}
+
+// Count returns the number of routes available in a RoutesResults
+func (rr *RoutesResults) Count() int {
+	return len(rr.Routes)
+}
+
+// Routes lists the routes of the scope, or the ones related to the objects given in req.Within.
+//
+// It is context aware.
+func (scope *Scope) Routes(ctx context.Context, req PTRefRequest) (*RoutesResults, error) {
+	var results = &RoutesResults{session: scope.session}
+	err := scope.ptref(ctx, req, routesEndpoint, "", results)
+	return results, err
+}
+
+// RouteByID provides information about a specific route.
+//
+// It is context aware.
+func (scope *Scope) RouteByID(ctx context.Context, req PTRefRequest, id types.ID) (*RoutesResults, error) {
+	var results = &RoutesResults{session: scope.session}
+	err := scope.ptref(ctx, req, routesEndpoint, id, results)
+	return results, err
+}
+
+// StopAreasResults holds the results of a stop areas request.
+type StopAreasResults struct {
+	StopAreas []types.StopArea `json:"stop_areas"`
+
+	Paging Paging `json:"links"`
+
+	// Pagination describes the position of this page in the results
+	Pagination Pagination `json:"pagination"`
+
+	// Context holds the timezone of the region, in which the date times of the results are expressed
+	Context types.Context `json:"context"`
+
+	Logging `json:"-"`
+
+	session *Session
+}
+
+// Count returns the number of stop areas available in a StopAreasResults
+func (sar *StopAreasResults) Count() int {
+	return len(sar.StopAreas)
+}
+
+// StopAreas lists the stop areas of the scope, or the ones related to the objects given in req.Within.
+//
+// It is context aware.
+func (scope *Scope) StopAreas(ctx context.Context, req PTRefRequest) (*StopAreasResults, error) {
+	var results = &StopAreasResults{session: scope.session}
+	err := scope.ptref(ctx, req, stopAreasEndpoint, "", results)
+	return results, err
+}
+
+// StopAreaByID provides information about a specific stop area.
+//
+// It is context aware.
+func (scope *Scope) StopAreaByID(ctx context.Context, req PTRefRequest, id types.ID) (*StopAreasResults, error) {
+	var results = &StopAreasResults{session: scope.session}
+	err := scope.ptref(ctx, req, stopAreasEndpoint, id, results)
+	return results, err
+}
+
+// StopPointsResults holds the results of a stop points request.
+type StopPointsResults struct {
+	StopPoints []types.StopPoint `json:"stop_points"`
+
+	Paging Paging `json:"links"`
+
+	// Pagination describes the position of this page in the results
+	Pagination Pagination `json:"pagination"`
+
+	// Context holds the timezone of the region, in which the date times of the results are expressed
+	Context types.Context `json:"context"`
+
+	Logging `json:"-"`
+
+	session *Session
+}
+
+// Count returns the number of stop points available in a StopPointsResults
+func (spr *StopPointsResults) Count() int {
+	return len(spr.StopPoints)
+}
+
+// StopPoints lists the stop points of the scope, or the ones related to the objects given in req.Within.
+//
+// It is context aware.
+func (scope *Scope) StopPoints(ctx context.Context, req PTRefRequest) (*StopPointsResults, error) {
+	var results = &StopPointsResults{session: scope.session}
+	err := scope.ptref(ctx, req, stopPointsEndpoint, "", results)
+	return results, err
+}
+
+// StopPointByID provides information about a specific stop point.
+//
+// It is context aware.
+func (scope *Scope) StopPointByID(ctx context.Context, req PTRefRequest, id types.ID) (*StopPointsResults, error) {
+	var results = &StopPointsResults{session: scope.session}
+	err := scope.ptref(ctx, req, stopPointsEndpoint, id, results)
+	return results, err
+}
+
+// NetworksResults holds the results of a networks request.
+type NetworksResults struct {
+	Networks []types.Network `json:"networks"`
+
+	Paging Paging `json:"links"`
+
+	// Pagination describes the position of this page in the results
+	Pagination Pagination `json:"pagination"`
+
+	// Context holds the timezone of the region, in which the date times of the results are expressed
+	Context types.Context `json:"context"`
+
+	Logging `json:"-"`
+
+	session *Session
+}
+
+// Count returns the number of networks available in a NetworksResults
+func (nr *NetworksResults) Count() int {
+	return len(nr.Networks)
+}
+
+// Networks lists the networks of the scope, or the ones related to the objects given in req.Within.
+//
+// It is context aware.
+func (scope *Scope) Networks(ctx context.Context, req PTRefRequest) (*NetworksResults, error) {
+	var results = &NetworksResults{session: scope.session}
+	err := scope.ptref(ctx, req, networksEndpoint, "", results)
+	return results, err
+}
+
+// NetworkByID provides information about a specific network.
+//
+// It is context aware.
+func (scope *Scope) NetworkByID(ctx context.Context, req PTRefRequest, id types.ID) (*NetworksResults, error) {
+	var results = &NetworksResults{session: scope.session}
+	err := scope.ptref(ctx, req, networksEndpoint, id, results)
+	return results, err
+}
+
+// CompaniesResults holds the results of a companies request.
+type CompaniesResults struct {
+	Companies []types.Company `json:"companies"`
+
+	Paging Paging `json:"links"`
+
+	// Pagination describes the position of this page in the results
+	Pagination Pagination `json:"pagination"`
+
+	// Context holds the timezone of the region, in which the date times of the results are expressed
+	Context types.Context `json:"context"`
+
+	Logging `json:"-"`
+
+	session *Session
+}
+
+// Count returns the number of companies available in a CompaniesResults
+func (cr *CompaniesResults) Count() int {
+	return len(cr.Companies)
+}
+
+// Companies lists the companies of the scope, or the ones related to the objects given in req.Within.
+//
+// It is context aware.
+func (scope *Scope) Companies(ctx context.Context, req PTRefRequest) (*CompaniesResults, error) {
+	var results = &CompaniesResults{session: scope.session}
+	err := scope.ptref(ctx, req, companiesEndpoint, "", results)
+	return results, err
+}
+
+// CompanyByID provides information about a specific company.
+//
+// It is context aware.
+func (scope *Scope) CompanyByID(ctx context.Context, req PTRefRequest, id types.ID) (*CompaniesResults, error) {
+	var results = &CompaniesResults{session: scope.session}
+	err := scope.ptref(ctx, req, companiesEndpoint, id, results)
+	return results, err
+}
+
+// PhysicalModesResults holds the results of a physical modes request.
+type PhysicalModesResults struct {
+	PhysicalModes []types.PhysicalMode `json:"physical_modes"`
+
+	Paging Paging `json:"links"`
+
+	// Pagination describes the position of this page in the results
+	Pagination Pagination `json:"pagination"`
+
+	// Context holds the timezone of the region, in which the date times of the results are expressed
+	Context types.Context `json:"context"`
+
+	Logging `json:"-"`
+
+	session *Session
+}
+
+// Count returns the number of physical modes available in a PhysicalModesResults
+func (pmr *PhysicalModesResults) Count() int {
+	return len(pmr.PhysicalModes)
+}
+
+// PhysicalModes lists the physical modes of the scope, or the ones related to the objects given in req.Within.
+//
+// It is context aware.
+func (scope *Scope) PhysicalModes(ctx context.Context, req PTRefRequest) (*PhysicalModesResults, error) {
+	var results = &PhysicalModesResults{session: scope.session}
+	err := scope.ptref(ctx, req, physicalModesEndpoint, "", results)
+	return results, err
+}
+
+// PhysicalModeByID provides information about a specific physical mode.
+//
+// It is context aware.
+func (scope *Scope) PhysicalModeByID(ctx context.Context, req PTRefRequest, id types.ID) (*PhysicalModesResults, error) {
+	var results = &PhysicalModesResults{session: scope.session}
+	err := scope.ptref(ctx, req, physicalModesEndpoint, id, results)
+	return results, err
+}
+
+// CommercialModesResults holds the results of a commercial modes request.
+type CommercialModesResults struct {
+	CommercialModes []types.CommercialMode `json:"commercial_modes"`
+
+	Paging Paging `json:"links"`
+
+	// Pagination describes the position of this page in the results
+	Pagination Pagination `json:"pagination"`
+
+	// Context holds the timezone of the region, in which the date times of the results are expressed
+	Context types.Context `json:"context"`
+
+	Logging `json:"-"`
+
+	session *Session
+}
+
+// Count returns the number of commercial modes available in a CommercialModesResults
+func (cmr *CommercialModesResults) Count() int {
+	return len(cmr.CommercialModes)
+}
+
+// CommercialModes lists the commercial modes of the scope, or the ones related to the objects given in req.Within.
+//
+// It is context aware.
+func (scope *Scope) CommercialModes(ctx context.Context, req PTRefRequest) (*CommercialModesResults, error) {
+	var results = &CommercialModesResults{session: scope.session}
+	err := scope.ptref(ctx, req, commercialModesEndpoint, "", results)
+	return results, err
+}
+
+// CommercialModeByID provides information about a specific commercial mode.
+//
+// It is context aware.
+func (scope *Scope) CommercialModeByID(ctx context.Context, req PTRefRequest, id types.ID) (*CommercialModesResults, error) {
+	var results = &CommercialModesResults{session: scope.session}
+	err := scope.ptref(ctx, req, commercialModesEndpoint, id, results)
+	return results, err
+}
+
+// VehicleJourneysResults holds the results of a vehicle journeys request.
+type VehicleJourneysResults struct {
+	VehicleJourneys []types.VehicleJourney `json:"vehicle_journeys"`
+
+	Paging Paging `json:"links"`
+
+	// Pagination describes the position of this page in the results
+	Pagination Pagination `json:"pagination"`
+
+	// Context holds the timezone of the region, in which the date times of the results are expressed
+	Context types.Context `json:"context"`
+
+	Logging `json:"-"`
+
+	session *Session
+}
+
+// Count returns the number of vehicle journeys available in a VehicleJourneysResults
+func (vjr *VehicleJourneysResults) Count() int {
+	return len(vjr.VehicleJourneys)
+}
+
+// VehicleJourneys lists the vehicle journeys of the scope, or the ones related to the objects given in req.Within.
+//
+// It is context aware.
+func (scope *Scope) VehicleJourneys(ctx context.Context, req PTRefRequest) (*VehicleJourneysResults, error) {
+	var results = &VehicleJourneysResults{session: scope.session}
+	err := scope.ptref(ctx, req, vehicleJourneysEndpoint, "", results)
+	return results, err
+}
+
+// VehicleJourneyByID provides information about a specific vehicle journey.
+//
+// It is context aware.
+func (scope *Scope) VehicleJourneyByID(ctx context.Context, req PTRefRequest, id types.ID) (*VehicleJourneysResults, error) {
+	var results = &VehicleJourneysResults{session: scope.session}
+	err := scope.ptref(ctx, req, vehicleJourneysEndpoint, id, results)
+	return results, err
+}
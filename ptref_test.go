@@ -0,0 +1,129 @@
+package navitia
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/aabizri/navitia/filter"
+	"github.com/aabizri/navitia/types"
+)
+
+func Test_PTRefRequest_toUrl(t *testing.T) {
+	// Declare this test to be run in parallel
+	t.Parallel()
+
+	req, err := PTRefRequest{Geo: true}.toURL()
+	if err != nil {
+		t.Fatalf("error in PTRefRequest.toURL: %v\n\tReceived: %#v", err, req)
+	}
+	if len(req) != 0 {
+		t.Fatalf("error in PTRefRequest.toURL: toURL created fields for non-specified parameters\n\tReceived: %#v", req)
+	}
+}
+
+// Test_PTRefRequest_toUrl_filter checks that filters are rendered, and that invalid ones are rejected
+func Test_PTRefRequest_toUrl_filter(t *testing.T) {
+	// Declare this test to be run in parallel
+	t.Parallel()
+
+	req, err := PTRefRequest{Filter: filter.Line.Code.Eq("6")}.toURL()
+	if err != nil {
+		t.Fatalf("error in PTRefRequest.toURL: %v\n\tReceived: %#v", err, req)
+	}
+	if got := req.Get("filter"); got != "line.code=6" {
+		t.Errorf("error in PTRefRequest.toURL: expected filter line.code=6, got %s", got)
+	}
+
+	_, err = PTRefRequest{Filter: filter.Line.Code.Eq("")}.toURL()
+	if err == nil {
+		t.Errorf("error in PTRefRequest.toURL: expected an error for an invalid filter, got none")
+	}
+}
+
+// Test_Scope_ptrefURL checks the building of nested paths
+func Test_Scope_ptrefURL(t *testing.T) {
+	// Declare this test to be run in parallel
+	t.Parallel()
+
+	scope := (&Session{APIURL: "http://localhost"}).Scope("fr-idf")
+
+	// Test cases
+	tests := []struct {
+		within     []types.ID
+		collection string
+		id         types.ID
+		expected   string
+	}{
+		{nil, linesEndpoint, "", "http://localhost/coverage/fr-idf/lines"},
+		{nil, linesEndpoint, "line:RAT:M6", "http://localhost/coverage/fr-idf/lines/line:RAT:M6"},
+		{[]types.ID{"line:RAT:M6"}, stopAreasEndpoint, "", "http://localhost/coverage/fr-idf/lines/line:RAT:M6/stop_areas"},
+		{[]types.ID{"network:RAT", "line:RAT:M6"}, routesEndpoint, "", "http://localhost/coverage/fr-idf/networks/network:RAT/lines/line:RAT:M6/routes"},
+	}
+
+	for i, test := range tests {
+		url, err := scope.ptrefURL(PTRefRequest{Within: test.within}, test.collection, test.id)
+		if err != nil {
+			t.Errorf("case #%d: unexpected error: %v", i, err)
+		} else if url != test.expected {
+			t.Errorf("case #%d: expected %s, got %s", i, test.expected, url)
+		}
+	}
+
+	// An object of unknown type can't be nested
+	if url, err := scope.ptrefURL(PTRefRequest{Within: []types.ID{"unknown"}}, linesEndpoint, ""); err == nil {
+		t.Errorf("expected an error when nesting an object of unknown type, got url %s", url)
+	}
+}
+
+// Test_LinesResults_Unmarshal tests unmarshalling for LinesResults.
+// As the unmarshalling is done by encoding/json, this allows us to check that the input can be reliably unmarshalled into the structure we have for it.
+//
+// This launches both a "correct" and "incorrect" subtest, allowing us to test both cases.
+// 	If we expect no errors but we get one, the test fails
+//	If we expect an error but we don't get one, the test fails
+func Test_LinesResults_Unmarshal(t *testing.T) {
+	testUnmarshal(t, testData["lines"], reflect.TypeOf(LinesResults{}))
+}
+
+// Test_StopAreasResults_Unmarshal tests unmarshalling for StopAreasResults.
+// As the unmarshalling is done by encoding/json, this allows us to check that the input can be reliably unmarshalled into the structure we have for it.
+//
+// This launches both a "correct" and "incorrect" subtest, allowing us to test both cases.
+// 	If we expect no errors but we get one, the test fails
+//	If we expect an error but we don't get one, the test fails
+func Test_StopAreasResults_Unmarshal(t *testing.T) {
+	testUnmarshal(t, testData["stop_areas"], reflect.TypeOf(StopAreasResults{}))
+}
+
+// Test_VehicleJourneysResults_Unmarshal tests unmarshalling for VehicleJourneysResults.
+// As the unmarshalling is done by encoding/json, this allows us to check that the input can be reliably unmarshalled into the structure we have for it.
+//
+// This launches both a "correct" and "incorrect" subtest, allowing us to test both cases.
+// 	If we expect no errors but we get one, the test fails
+//	If we expect an error but we don't get one, the test fails
+func Test_VehicleJourneysResults_Unmarshal(t *testing.T) {
+	testUnmarshal(t, testData["vehicle_journeys"], reflect.TypeOf(VehicleJourneysResults{}))
+}
+
+// Test_PTRefRequest_toUrl_depth checks that a depth of 0 can be requested, and that depths above 3 are rejected
+func Test_PTRefRequest_toUrl_depth(t *testing.T) {
+	// Declare this test to be run in parallel
+	t.Parallel()
+
+	for _, depth := range []uint{0, 3} {
+		depth := depth
+		req, err := PTRefRequest{Depth: &depth}.toURL()
+		if err != nil {
+			t.Fatalf("error in PTRefRequest.toURL: %v\n\tReceived: %#v", err, req)
+		}
+		if got, expected := req.Get("depth"), strconv.FormatUint(uint64(depth), 10); got != expected {
+			t.Errorf("error in PTRefRequest.toURL: expected depth %s, got %q", expected, got)
+		}
+	}
+
+	depth := uint(4)
+	if _, err := (PTRefRequest{Depth: &depth}).toURL(); err == nil {
+		t.Errorf("error in PTRefRequest.toURL: expected an error for a depth of 4, got none")
+	}
+}
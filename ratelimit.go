@@ -0,0 +1,187 @@
+package navitia
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrLimiterDeadline is returned when a request can't be made before the deadline of its context because of the Session's Limiter
+var ErrLimiterDeadline = errors.New("navitia: rate limiter would exceed the context deadline")
+
+// A Limiter is a token-bucket rate limiter, limiting the requests made by a Session, and by every Scope derived from it.
+//
+// The bucket holds at most burst tokens, and is refilled at rate tokens per second, each request consuming a token.
+// It is safe for concurrent use.
+type Limiter struct {
+	rate  float64
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// NewLimiter creates a Limiter allowing rate requests per second on average, with bursts of at most burst requests.
+// The bucket starts full.
+func NewLimiter(rate float64, burst uint) *Limiter {
+	if burst == 0 {
+		burst = 1
+	}
+	return &Limiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// advance refills the bucket up to now, it must be called with the mutex held
+func (l *Limiter) advance(now time.Time) {
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		l.tokens += elapsed.Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+		l.last = now
+	}
+}
+
+// Allow reports whether a request can be made now, consuming a token if so.
+func (l *Limiter) Allow() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.advance(time.Now())
+	if l.tokens < 1 {
+		return false
+	}
+	l.tokens--
+	return true
+}
+
+// Wait blocks until a request can be made, consuming a token.
+//
+// If the context is done before that, it returns the context's error.
+// If the context has a deadline before which no token will be available, it fails fast with ErrLimiterDeadline.
+func (l *Limiter) Wait(ctx context.Context) error {
+	// Reserve a token
+	l.mu.Lock()
+	now := time.Now()
+	l.advance(now)
+	l.tokens--
+	var delay time.Duration
+	if l.tokens < 0 {
+		if l.rate <= 0 {
+			l.tokens++
+			l.mu.Unlock()
+			return errors.New("navitia: rate limiter has a null rate and no token left")
+		}
+		delay = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+
+	// Fail fast if the deadline would be exceeded
+	if deadline, ok := ctx.Deadline(); ok && now.Add(delay).After(deadline) {
+		l.tokens++
+		l.mu.Unlock()
+		return ErrLimiterDeadline
+	}
+	l.mu.Unlock()
+
+	if delay == 0 {
+		return nil
+	}
+
+	// Wait for our token
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// Give back the token
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// A Quota is a snapshot of the request quota of an API key, as reported by the server in the rate-limit headers of its responses.
+type Quota struct {
+	// Limit is the number of requests allowed in the current window
+	Limit int
+
+	// Remaining is the number of requests left in the current window
+	Remaining int
+
+	// Reset is when the window resets
+	Reset time.Time
+
+	// Updated is when this snapshot was taken, it is the zero value if the server never reported any quota
+	Updated time.Time
+}
+
+// Known reports whether the server reported a quota
+func (q Quota) Known() bool {
+	return !q.Updated.IsZero()
+}
+
+// The rate-limit headers parsed into a Quota
+const (
+	quotaLimitHeader     = "X-RateLimit-Limit"
+	quotaRemainingHeader = "X-RateLimit-Remaining"
+	quotaResetHeader     = "X-RateLimit-Reset"
+)
+
+// parseQuota parses the rate-limit headers, returning false if there are none
+//
+// The reset header can be either a unix timestamp or a number of seconds from now.
+func parseQuota(header http.Header, now time.Time) (Quota, bool) {
+	q := Quota{Updated: now}
+	var found bool
+
+	if v, err := strconv.Atoi(header.Get(quotaLimitHeader)); err == nil {
+		q.Limit = v
+		found = true
+	}
+	if v, err := strconv.Atoi(header.Get(quotaRemainingHeader)); err == nil {
+		q.Remaining = v
+		found = true
+	}
+	if v, err := strconv.ParseInt(header.Get(quotaResetHeader), 10, 64); err == nil {
+		// Let's say that anything over a billion seconds is a timestamp
+		if v > 1e9 {
+			q.Reset = time.Unix(v, 0)
+		} else {
+			q.Reset = now.Add(time.Duration(v) * time.Second)
+		}
+		found = true
+	}
+
+	return q, found
+}
+
+// updateQuota updates the quota snapshot of the session with the headers of a response, if they report any
+func (s *Session) updateQuota(header http.Header) {
+	q, ok := parseQuota(header, time.Now())
+	if !ok {
+		return
+	}
+	s.quotaMu.Lock()
+	s.quota = q
+	s.quotaMu.Unlock()
+}
+
+// Quota returns a snapshot of the request quota of the session's API key, as last reported by the server.
+//
+// If the server never reported any, the returned Quota isn't Known.
+func (s *Session) Quota() Quota {
+	s.quotaMu.Lock()
+	defer s.quotaMu.Unlock()
+	return s.quota
+}
@@ -0,0 +1,108 @@
+package navitia
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func Test_Limiter(t *testing.T) {
+	// Declare this test to be run in parallel
+	t.Parallel()
+
+	l := NewLimiter(20, 2)
+
+	// The burst is available right away
+	if !l.Allow() || !l.Allow() {
+		t.Fatalf("expected the burst to be allowed")
+	}
+	if l.Allow() {
+		t.Fatalf("expected the bucket to be empty after the burst")
+	}
+
+	// Waiting gives a token after about 1/20s
+	start := time.Now()
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatalf("unexpected error in Wait: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 25*time.Millisecond {
+		t.Errorf("expected Wait to block for about 50ms, it blocked for %v", elapsed)
+	}
+
+	// A deadline that's too close fails fast
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	start = time.Now()
+	if err := l.Wait(ctx); err != ErrLimiterDeadline {
+		t.Errorf("expected ErrLimiterDeadline, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 25*time.Millisecond {
+		t.Errorf("expected Wait to fail fast, it blocked for %v", elapsed)
+	}
+}
+
+func Test_parseQuota(t *testing.T) {
+	// Declare this test to be run in parallel
+	t.Parallel()
+
+	now := time.Date(2017, 4, 27, 17, 0, 0, 0, time.UTC)
+
+	header := http.Header{}
+	if _, ok := parseQuota(header, now); ok {
+		t.Errorf("expected no quota without headers")
+	}
+
+	header.Set("X-RateLimit-Limit", "5000")
+	header.Set("X-RateLimit-Remaining", "4998")
+	header.Set("X-RateLimit-Reset", "60")
+	q, ok := parseQuota(header, now)
+	if !ok || q.Limit != 5000 || q.Remaining != 4998 || !q.Reset.Equal(now.Add(time.Minute)) {
+		t.Errorf("unexpected quota: %#v", q)
+	}
+
+	header.Set("X-RateLimit-Reset", "1493312400")
+	q, _ = parseQuota(header, now)
+	if !q.Reset.Equal(time.Unix(1493312400, 0)) {
+		t.Errorf("expected the reset to be parsed as a timestamp, got %v", q.Reset)
+	}
+}
+
+func Test_Session_Quota(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "10")
+		w.Header().Set("X-RateLimit-Remaining", "9")
+		fmt.Fprint(w, `{"places": []}`)
+	}))
+	defer ts.Close()
+
+	s, err := NewCustom("", ts.URL, http.DefaultClient)
+	if err != nil {
+		t.Fatalf("error while creating session: %v", err)
+	}
+	s.Limiter = NewLimiter(1, 1)
+
+	if s.Quota().Known() {
+		t.Fatalf("expected no quota before any request")
+	}
+
+	_, err = s.Places(context.Background(), PlacesRequest{Query: "test"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if q := s.Quota(); !q.Known() || q.Limit != 10 || q.Remaining != 9 {
+		t.Errorf("unexpected quota: %#v", q)
+	}
+
+	// The limiter is shared by the scopes, and the bucket is now empty
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = s.Scope("sandbox").Places(ctx, PlacesRequest{Query: "test"})
+	if !errors.Is(err, ErrLimiterDeadline) {
+		t.Errorf("expected ErrLimiterDeadline, got %v", err)
+	}
+}
@@ -0,0 +1,326 @@
+package navitia
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"time"
+	"unsafe"
+
+	"github.com/aabizri/navitia/types"
+	"github.com/pkg/errors"
+)
+
+// TrafficReportsResults holds the results of a traffic reports request.
+type TrafficReportsResults struct {
+	TrafficReports []types.TrafficReport
+
+	// Disruptions lists every disruption referenced in the traffic reports
+	Disruptions []types.Disruption
+
+	Paging Paging `json:"links"`
+
+	// Pagination describes the position of this page in the results
+	Pagination Pagination `json:"pagination"`
+
+	// Context holds the timezone of the region, in which the date times of the results are expressed
+	Context types.Context `json:"context"`
+
+	Logging `json:"-"`
+
+	session *Session
+}
+
+// Count returns the number of traffic reports available in a TrafficReportsResults
+func (trr *TrafficReportsResults) Count() int {
+	return len(trr.TrafficReports)
+}
+
+// UnmarshalJSON implements unmarshalling for TrafficReportsResults.
+//
+// It resolves the internal links to disruptions of every network, line and stop area.
+func (trr *TrafficReportsResults) UnmarshalJSON(b []byte) error {
+	// First let's create the analogous structure
+	// We define some of the value as pointers to the real values, allowing us to bypass copying in cases where we don't need to process the data
+	data := &struct {
+		// Pointers to the corresponding real values
+		Paging      *Paging             `json:"links"`
+		Pagination  *Pagination         `json:"pagination"`
+		Disruptions *[]types.Disruption `json:"disruptions"`
+		Context     *types.Context      `json:"context"`
+
+		// Values to process
+		TrafficReports []struct {
+			Network   json.RawMessage   `json:"network"`
+			Lines     []json.RawMessage `json:"lines"`
+			StopAreas []json.RawMessage `json:"stop_areas"`
+		} `json:"traffic_reports"`
+	}{
+		Paging:      &trr.Paging,
+		Pagination:  &trr.Pagination,
+		Disruptions: &trr.Disruptions,
+		Context:     &trr.Context,
+	}
+
+	// Now unmarshall the raw data into the analogous structure
+	err := json.Unmarshal(b, data)
+	if err != nil {
+		return errors.Wrap(err, "TrafficReportsResults.UnmarshalJSON: error while unmarshalling")
+	}
+
+	// Index the disruptions
+	index := indexDisruptions(trr.Disruptions)
+
+	// Now process the values
+	trr.TrafficReports = make([]types.TrafficReport, len(data.TrafficReports))
+	for i, raw := range data.TrafficReports {
+		report := &trr.TrafficReports[i]
+
+		if len(raw.Network) != 0 {
+			report.Disruptions, err = unmarshalDisrupted(raw.Network, &report.Network, index)
+			if err != nil {
+				return errors.Wrapf(err, "TrafficReportsResults.UnmarshalJSON: error while unmarshalling network of report #%d", i)
+			}
+		}
+
+		report.Lines = make([]types.DisruptedLine, len(raw.Lines))
+		for j, rawLine := range raw.Lines {
+			dl := &report.Lines[j]
+			dl.Disruptions, err = unmarshalDisrupted(rawLine, &dl.Line, index)
+			if err != nil {
+				return errors.Wrapf(err, "TrafficReportsResults.UnmarshalJSON: error while unmarshalling line #%d of report #%d", j, i)
+			}
+		}
+
+		report.StopAreas = make([]types.DisruptedStopArea, len(raw.StopAreas))
+		for j, rawSA := range raw.StopAreas {
+			dsa := &report.StopAreas[j]
+			dsa.Disruptions, err = unmarshalDisrupted(rawSA, &dsa.StopArea, index)
+			if err != nil {
+				return errors.Wrapf(err, "TrafficReportsResults.UnmarshalJSON: error while unmarshalling stop area #%d of report #%d", j, i)
+			}
+		}
+	}
+
+	return nil
+}
+
+// LineReportsResults holds the results of a line reports request.
+type LineReportsResults struct {
+	LineReports []types.LineReport
+
+	// Disruptions lists every disruption referenced in the line reports
+	Disruptions []types.Disruption
+
+	Paging Paging `json:"links"`
+
+	// Pagination describes the position of this page in the results
+	Pagination Pagination `json:"pagination"`
+
+	// Context holds the timezone of the region, in which the date times of the results are expressed
+	Context types.Context `json:"context"`
+
+	Logging `json:"-"`
+
+	session *Session
+}
+
+// Count returns the number of line reports available in a LineReportsResults
+func (lrr *LineReportsResults) Count() int {
+	return len(lrr.LineReports)
+}
+
+// UnmarshalJSON implements unmarshalling for LineReportsResults.
+//
+// It resolves the internal links to disruptions of every line and public transport object.
+func (lrr *LineReportsResults) UnmarshalJSON(b []byte) error {
+	// First let's create the analogous structure
+	// We define some of the value as pointers to the real values, allowing us to bypass copying in cases where we don't need to process the data
+	data := &struct {
+		// Pointers to the corresponding real values
+		Paging      *Paging             `json:"links"`
+		Pagination  *Pagination         `json:"pagination"`
+		Disruptions *[]types.Disruption `json:"disruptions"`
+		Context     *types.Context      `json:"context"`
+
+		// Values to process
+		LineReports []struct {
+			Line      json.RawMessage   `json:"line"`
+			PTObjects []json.RawMessage `json:"pt_objects"`
+		} `json:"line_reports"`
+	}{
+		Paging:      &lrr.Paging,
+		Pagination:  &lrr.Pagination,
+		Disruptions: &lrr.Disruptions,
+		Context:     &lrr.Context,
+	}
+
+	// Now unmarshall the raw data into the analogous structure
+	err := json.Unmarshal(b, data)
+	if err != nil {
+		return errors.Wrap(err, "LineReportsResults.UnmarshalJSON: error while unmarshalling")
+	}
+
+	// Index the disruptions
+	index := indexDisruptions(lrr.Disruptions)
+
+	// Now process the values
+	lrr.LineReports = make([]types.LineReport, len(data.LineReports))
+	for i, raw := range data.LineReports {
+		report := &lrr.LineReports[i]
+
+		if len(raw.Line) != 0 {
+			report.Disruptions, err = unmarshalDisrupted(raw.Line, &report.Line, index)
+			if err != nil {
+				return errors.Wrapf(err, "LineReportsResults.UnmarshalJSON: error while unmarshalling line of report #%d", i)
+			}
+		}
+
+		report.PTObjects = make([]types.DisruptedObject, len(raw.PTObjects))
+		for j, rawObj := range raw.PTObjects {
+			do := &report.PTObjects[j]
+			do.Disruptions, err = unmarshalDisrupted(rawObj, &do.Object, index)
+			if err != nil {
+				return errors.Wrapf(err, "LineReportsResults.UnmarshalJSON: error while unmarshalling pt object #%d of report #%d", j, i)
+			}
+		}
+	}
+
+	return nil
+}
+
+// indexDisruptions indexes disruptions by their ID
+func indexDisruptions(disruptions []types.Disruption) map[types.ID]types.Disruption {
+	index := make(map[types.ID]types.Disruption, len(disruptions))
+	for _, d := range disruptions {
+		index[d.ID] = d
+	}
+	return index
+}
+
+// unmarshalDisrupted unmarshals raw into v, and returns the disruptions referenced by the internal links of raw
+//
+// Disruptions unknown to the index are ignored.
+func unmarshalDisrupted(raw json.RawMessage, v interface{}, index map[types.ID]types.Disruption) ([]types.Disruption, error) {
+	// Unmarshal the object itself
+	err := json.Unmarshal(raw, v)
+	if err != nil {
+		return nil, err
+	}
+
+	// Then retrieve its links
+	data := &struct {
+		Links []link `json:"links"`
+	}{}
+	err = json.Unmarshal(raw, data)
+	if err != nil {
+		return nil, errors.Wrap(err, "error while unmarshalling links")
+	}
+
+	// And resolve them
+	var disruptions []types.Disruption
+	for _, l := range data.Links {
+		if l.Type != "disruption" {
+			continue
+		}
+		if d, ok := index[l.ID]; ok {
+			disruptions = append(disruptions, d)
+		}
+	}
+	return disruptions, nil
+}
+
+// ReportsRequest contains the optional parameters for a TrafficReports or LineReports request.
+type ReportsRequest struct {
+	// Since and Until restrict the results to the disruptions active during that period
+	Since time.Time
+	Until time.Time
+
+	// The maximum amount of reports per page
+	Count uint
+
+	// The page to retrieve
+	StartPage uint
+
+	// ForbiddenURIs
+	Forbidden []types.ID
+
+	// Enables GeoJSON data in the reply. GeoJSON objects can be VERY large ! >1MB.
+	Geo bool
+
+	// local is set once the date times are expressed in the region's timezone, see localQuery
+	local bool
+}
+
+// in returns the request with its date times expressed in the given location, see localQuery
+func (req ReportsRequest) in(loc *time.Location) query {
+	if loc != nil {
+		req.Since = req.Since.In(loc)
+		req.Until = req.Until.In(loc)
+		req.local = true
+	}
+	return req
+}
+
+func (req ReportsRequest) toURL() (url.Values, error) {
+	values := url.Values{}
+
+	if since := req.Since; !since.IsZero() {
+		values.Add("since", formatRequestDateTime(since, req.local))
+	}
+	if until := req.Until; !until.IsZero() {
+		values.Add("until", formatRequestDateTime(until, req.local))
+	}
+
+	if count := req.Count; count != 0 {
+		values.Add("count", strconv.FormatUint(uint64(count), 10))
+	}
+	if page := req.StartPage; page != 0 {
+		values.Add("start_page", strconv.FormatUint(uint64(page), 10))
+	}
+
+	// Deal with the forbidden URIs
+	if forbidden := req.Forbidden; len(forbidden) != 0 {
+		magic := *(*[]string)(unsafe.Pointer(&forbidden))
+		values["forbidden_uris[]"] = magic
+	}
+
+	// Add GEO
+	if !req.Geo {
+		values.Add("disable_geojson", "true")
+	}
+
+	return values, nil
+}
+
+const (
+	trafficReportsEndpoint string = "traffic_reports"
+	lineReportsEndpoint           = "line_reports"
+)
+
+// TrafficReports requests the traffic reports of a region: for each network, the disrupted network, lines & stop areas.
+//
+// It is context aware.
+func (scope *Scope) TrafficReports(ctx context.Context, req ReportsRequest) (*TrafficReportsResults, error) {
+	// Create the URL
+	url := scope.session.APIURL + "/coverage/" + string(scope.region) + "/" + trafficReportsEndpoint
+
+	// Call
+	var results = &TrafficReportsResults{session: scope.session}
+	err := scope.session.request(ctx, url, req, results)
+	return results, err
+}
+
+// LineReports requests the line reports of a region: for each disrupted line, the disrupted public transport objects.
+//
+// It is context aware.
+func (scope *Scope) LineReports(ctx context.Context, req ReportsRequest) (*LineReportsResults, error) {
+	// Create the URL
+	url := scope.session.APIURL + "/coverage/" + string(scope.region) + "/" + lineReportsEndpoint
+
+	// Call
+	var results = &LineReportsResults{session: scope.session}
+	err := scope.session.request(ctx, url, req, results)
+	return results, err
+}
@@ -0,0 +1,72 @@
+package navitia
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aabizri/navitia/types"
+)
+
+func Test_ReportsRequest_toUrl(t *testing.T) {
+	// Declare this test to be run in parallel
+	t.Parallel()
+
+	req, err := ReportsRequest{Geo: true}.toURL()
+	if err != nil {
+		t.Fatalf("error in ReportsRequest.toURL: %v\n\tReceived: %#v", err, req)
+	}
+	if len(req) != 0 {
+		t.Fatalf("error in ReportsRequest.toURL: toURL created fields for non-specified parameters\n\tReceived: %#v", req)
+	}
+}
+
+// Test_TrafficReportsResults_Unmarshal tests unmarshalling for TrafficReportsResults.
+// As the unmarshalling is done in-house, this allows us to check that the custom UnmarshalJSON function correctly
+//
+// This launches both a "correct" and "incorrect" subtest, allowing us to test both cases.
+// 	If we expect no errors but we get one, the test fails
+//	If we expect an error but we don't get one, the test fails
+func Test_TrafficReportsResults_Unmarshal(t *testing.T) {
+	testUnmarshal(t, testData["traffic_reports"], reflect.TypeOf(TrafficReportsResults{}))
+}
+
+// Test_LineReportsResults_Unmarshal tests unmarshalling for LineReportsResults.
+// As the unmarshalling is done in-house, this allows us to check that the custom UnmarshalJSON function correctly
+//
+// This launches both a "correct" and "incorrect" subtest, allowing us to test both cases.
+// 	If we expect no errors but we get one, the test fails
+//	If we expect an error but we don't get one, the test fails
+func Test_LineReportsResults_Unmarshal(t *testing.T) {
+	testUnmarshal(t, testData["line_reports"], reflect.TypeOf(LineReportsResults{}))
+}
+
+// Test_TrafficReportsResults_Disruptions checks that the disruptions links are resolved for each object
+func Test_TrafficReportsResults_Disruptions(t *testing.T) {
+	data, ok := testData["traffic_reports"].correct["a.json"]
+	if !ok {
+		t.Skip("no data provided, skipping...")
+	}
+
+	var res = &TrafficReportsResults{}
+	err := res.UnmarshalJSON(data)
+	if err != nil {
+		t.Fatalf("error while unmarshalling: %v", err)
+	}
+	if res.Count() != 1 {
+		t.Fatalf("expected 1 traffic report, got %d", res.Count())
+	}
+	if res.Paging.Next == "" {
+		t.Errorf("expected a next page, got none")
+	}
+
+	// Check each object gets its own disruptions, unknown ones being ignored
+	report := res.TrafficReports[0]
+	check := func(name string, disruptions []types.Disruption, expected types.ID) {
+		if len(disruptions) != 1 || disruptions[0].ID != expected {
+			t.Errorf("%s: expected disruption %s, got %v", name, expected, disruptions)
+		}
+	}
+	check("network", report.Disruptions, "d-network")
+	check("line", report.Lines[0].Disruptions, "d-line")
+	check("stop area", report.StopAreas[0].Disruptions, "d-sa")
+}
@@ -3,10 +3,12 @@ package navitia
 import (
 	"context"
 	"encoding/json"
-	"io"
+	"io/ioutil"
 	"net/http"
 	"net/url"
+	"time"
 
+	"github.com/aabizri/navitia/types"
 	"github.com/pkg/errors"
 )
 
@@ -17,73 +19,203 @@ type query interface {
 // results is implemented by every Result type
 type results interface {
 	creating()
+	attempting()
+	attempted(timings Timings, resp *http.Response)
+	caching()
+	sharing()
 	sending()
 	parsing()
+	responded(url string, statusCode int, size int64)
+
+	// context returns the context of the response, see timezone.go
+	context() types.Context
+
+	// localize re-tags the local date times of the results with the given location
+	localize(loc *time.Location)
 }
 
-// requestURL requests a url, with the query already encoded in, and decodes the result in res.
-func (s *Session) requestURL(ctx context.Context, url string, res results) error {
+// fetch requests a url, with the query already encoded in, and decodes the result in res.
+//
+// The header of the call is added to the request, and if the call has a streamDecoder, the results are streamed through it, bypassing the cache.
+// The status code & size of the response are recorded in the call, as well as in the results' Logging.
+// Once decoded, the date times of the results are expressed in the timezone of their region.
+func (s *Session) fetch(ctx context.Context, url string, call *Call, res results) (err error) {
+	stream := call.stream
+
 	// Store creation time
 	res.creating()
 
+	// Record the response once done
+	defer func() {
+		res.responded(url, call.StatusCode, call.ResponseSize)
+	}()
+
+	// Re-tag the date times with the region's timezone once decoded
+	defer func() {
+		if err == nil {
+			s.localize(url, res)
+		}
+	}()
+
+	// Look up the cache
+	var ttl time.Duration
+	if stream == nil {
+		ttl = s.cacheTTL(url)
+	}
+	if ttl != 0 {
+		if body, ok := s.Cache.Get(s.requestKey(url, call.Header)); ok {
+			res.caching()
+			call.ResponseSize = int64(len(body))
+			err := json.Unmarshal(body, res)
+			if err != nil {
+				return errors.Wrap(err, "JSON decoding of cached response failed")
+			}
+			res.parsing()
+			return nil
+		}
+	}
+
+	// Coalesce identical concurrent calls
+	if s.Coalesce && stream == nil {
+		return s.fetchShared(ctx, url, call, res, ttl)
+	}
+
+	// Send the request
+	resp, reader, err := s.send(ctx, url, call, res)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// If the response can be cached, keep its body
+	if ttl = responseTTL(ttl, resp.Header, time.Now()); ttl != 0 {
+		body, err := readAll(reader, call)
+		if err != nil {
+			return err
+		}
+		err = json.Unmarshal(body, res)
+		if err != nil {
+			return errors.Wrap(err, "JSON decoding failed")
+		}
+		s.Cache.Set(s.requestKey(url, call.Header), body, ttl)
+		res.parsing()
+		return nil
+	}
+
+	// Parse the now limited body
+	dec := json.NewDecoder(reader)
+	if stream != nil {
+		err = stream.decode(dec, res)
+	} else {
+		err = dec.Decode(res)
+	}
+	call.ResponseSize = reader.size()
+	if tooLarge := reader.exceeded(); tooLarge != nil {
+		return tooLarge
+	}
+	if cbErr, ok := err.(streamCallbackError); ok {
+		return cbErr.err
+	}
+	if err != nil {
+		return errors.Wrap(err, "JSON decoding failed")
+	}
+	res.parsing()
+
+	// Return
+	return err
+}
+
+// send sends the request of a call, returning the response along with its body limited in size.
+//
+// The status code of the response is recorded in the call, as well as its size if it is an error.
+// The caller must close the body of the response.
+func (s *Session) send(ctx context.Context, url string, call *Call, res results) (*http.Response, *sizeLimitedReader, error) {
 	// Create the request
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		return errors.Wrapf(err, "couldn't create new request (for %s)", url)
+		return nil, nil, errors.Wrapf(err, "couldn't create new request (for %s)", url)
 	}
 
 	// Add context to the request
 	req = req.WithContext(ctx)
 
-	// Add basic auth
-	req.SetBasicAuth(s.APIKey, "")
+	// Add the headers
+	if s.UserAgent != "" {
+		req.Header.Set("User-Agent", s.UserAgent)
+	}
+	for key, values := range call.Header {
+		req.Header[key] = values
+	}
+
+	// Add basic auth, only for the session's API as the links of the results may point elsewhere
+	if s.ownsURL(req.URL) {
+		req.SetBasicAuth(s.APIKey, "")
+	}
 
-	// Execute the request
-	resp, err := s.client.Do(req)
+	// Execute the request, retrying it if needed
+	resp, err := s.do(ctx, req, call, res)
 	res.sending()
 
 	// Check the response
-	if err != nil {
-		return errors.Wrap(err, "error while executing request")
+	if remoteErr, ok := err.(*RemoteError); ok {
+		call.StatusCode = remoteErr.StatusCode
+		call.ResponseSize = int64(len(remoteErr.Body))
 	}
-	if resp.StatusCode != 200 {
-		return parseRemoteError(resp)
+	if err != nil {
+		return nil, nil, err
 	}
-
-	// Defer the close
-	defer resp.Body.Close()
+	call.StatusCode = resp.StatusCode
 
 	// Check for cancellation
 	select {
 	case <-ctx.Done():
-		return ctx.Err()
+		resp.Body.Close()
+		return nil, nil, ctx.Err()
 	default:
 	}
 
 	// Limit the reader
-	reader := io.LimitReader(resp.Body, maxSize)
+	endpoint := endpointOf(url)
+	limit := s.maxResponseSize(endpoint)
+	if resp.ContentLength > limit {
+		resp.Body.Close()
+		return nil, nil, &ErrResponseTooLarge{Limit: limit, Endpoint: endpoint, URL: url}
+	}
+	return resp, newSizeLimitedReader(resp.Body, limit, endpoint, url), nil
+}
 
-	// Parse the now limited body
-	dec := json.NewDecoder(reader)
-	err = dec.Decode(res)
+// readAll reads a whole body limited in size, recording its size in the call
+func readAll(reader *sizeLimitedReader, call *Call) ([]byte, error) {
+	body, err := ioutil.ReadAll(reader)
+	call.ResponseSize = int64(len(body))
+	if tooLarge := reader.exceeded(); tooLarge != nil {
+		return nil, tooLarge
+	}
 	if err != nil {
-		return errors.Wrap(err, "JSON decoding failed")
+		return nil, errors.Wrap(err, "error while reading body")
 	}
-	res.parsing()
+	return body, nil
+}
 
-	// Return
-	return err
+// requestURL requests a url, with the query already encoded in, and decodes the result in res.
+//
+// It goes through the session's middlewares.
+func (s *Session) requestURL(ctx context.Context, url string, res results) error {
+	return s.call(ctx, &Call{URL: url, Results: res})
 }
 
 // request does a request given a url, query and results to populate
+//
+// It goes through the session's middlewares, the query being encoded after them.
 func (s *Session) request(ctx context.Context, baseURL string, query query, res results) error {
-	// Encode the parameters
-	values, err := query.toURL()
+	return s.call(ctx, &Call{URL: baseURL, Request: query, Results: res})
+}
+
+// ownsURL returns true if the url has the scheme & host of the session's API, the API key being only sent to it
+func (s *Session) ownsURL(u *url.URL) bool {
+	api, err := url.Parse(s.APIURL)
 	if err != nil {
-		return errors.Wrap(err, "error while retrieving url values to be encoded")
+		return false
 	}
-	url := baseURL + "?" + values.Encode()
-
-	// Call requestURL
-	return s.requestURL(ctx, url, res)
+	return u.Scheme == api.Scheme && u.Host == api.Host
 }
@@ -0,0 +1,207 @@
+package navitia
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// A RetryPolicy describes how a Session retries the requests that failed because of a transient error.
+//
+// Only idempotent requests (GET & HEAD) are retried, when they fail with a transient transport error (a timeout, a connection reset or refused, or a response cut short) or a retryable status code.
+// The delay between two attempts grows exponentially, is randomized by the jitter, and is at least the one given by a Retry-After header.
+// If waiting would go past the deadline of the request's context, the request isn't retried.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one
+	// If it is 0 or 1, requests aren't retried
+	MaxAttempts uint
+
+	// BaseDelay is the delay before the first retry, it is doubled at each attempt
+	BaseDelay time.Duration
+
+	// MaxDelay caps the delay between two attempts
+	// If a Retry-After header asks for more, the request isn't retried
+	// If it is 0, the delay isn't capped
+	MaxDelay time.Duration
+
+	// Jitter is the fraction of the delay which is randomized, between 0 and 1
+	// For example with a jitter of 0.5, a delay of 1s becomes a random delay between 0.5s and 1s
+	Jitter float64
+
+	// Statuses are the HTTP status codes which are retried
+	// If it is nil, DefaultRetryStatuses are used
+	Statuses []int
+}
+
+// DefaultRetryStatuses are the status codes retried when the RetryPolicy doesn't specify any
+var DefaultRetryStatuses = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// DefaultRetryPolicy is a sensible RetryPolicy, to be used as Session.Retry
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   200 * time.Millisecond,
+	MaxDelay:    5 * time.Second,
+	Jitter:      0.5,
+}
+
+// retryable reports whether a request which failed with either a transport error or a response should be retried
+func (policy *RetryPolicy) retryable(ctx context.Context, req *http.Request, resp *http.Response, err error) bool {
+	// Only idempotent requests are retried
+	if req.Method != "GET" && req.Method != "HEAD" {
+		return false
+	}
+
+	// Transport error: retry if it is transient, unless it comes from the context
+	if resp == nil {
+		return ctx.Err() == nil && transient(err)
+	}
+
+	// Check the status code
+	statuses := policy.Statuses
+	if statuses == nil {
+		statuses = DefaultRetryStatuses
+	}
+	for _, code := range statuses {
+		if resp.StatusCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+// transient reports whether a transport error is transient: a timeout, a connection reset or refused, or a response cut short.
+// Other errors, such as DNS or TLS certificate errors, are permanent.
+func transient(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// backoff returns the delay to wait after the given attempt
+func (policy *RetryPolicy) backoff(attempt uint) time.Duration {
+	delay := policy.BaseDelay
+	for i := uint(1); i < attempt; i++ {
+		delay *= 2
+		if policy.MaxDelay != 0 && delay >= policy.MaxDelay {
+			break
+		}
+	}
+	if policy.MaxDelay != 0 && delay > policy.MaxDelay {
+		delay = policy.MaxDelay
+	}
+
+	// Apply the jitter
+	if jitter := policy.Jitter; jitter > 0 {
+		if jitter > 1 {
+			jitter = 1
+		}
+		delay -= time.Duration(jitter * rand.Float64() * float64(delay))
+	}
+	return delay
+}
+
+// parseRetryAfter parses the value of a Retry-After header, either a number of seconds or an HTTP date, returning 0 if there is none or if it is invalid
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.ParseUint(value, 10, 32); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
+
+// do executes the request, retrying it according to the session's RetryPolicy, and records each attempt & its timings in res.
+// Each attempt waits for the session's Limiter, and updates the session's Quota.
+// If the request isn't retried because of the deadline of its context, the call is marked as such.
+//
+// It returns the response only if its status is 200 OK, otherwise it returns the error.
+func (s *Session) do(ctx context.Context, req *http.Request, call *Call, res results) (*http.Response, error) {
+	policy := s.Retry
+	for attempt := uint(1); ; attempt++ {
+		// Wait for the rate limiter
+		if s.Limiter != nil {
+			if err := s.Limiter.Wait(ctx); err != nil {
+				return nil, errors.Wrap(err, "error while waiting for the rate limiter")
+			}
+		}
+
+		res.attempting()
+
+		// Execute the request, tracing its timings
+		tt := &timingsTrace{}
+		resp, err := s.client.Do(req.WithContext(httptrace.WithClientTrace(req.Context(), tt.clientTrace())))
+		res.attempted(tt.get(), resp)
+		if err == nil {
+			s.updateQuota(resp.Header)
+		}
+
+		// Log the attempt
+		if s.Logger != nil {
+			if err != nil {
+				s.Logger.Printf("navitia: %s %s (attempt #%d): %v", req.Method, req.URL, attempt, err)
+			} else {
+				s.Logger.Printf("navitia: %s %s (attempt #%d): %s", req.Method, req.URL, attempt, resp.Status)
+			}
+		}
+		if err == nil && resp.StatusCode == 200 {
+			return resp, nil
+		}
+
+		// Build the error
+		var retryAfter time.Duration
+		transportErr := err
+		if err != nil {
+			err = errors.Wrap(err, "error while executing request")
+		} else {
+			retryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+			err = parseRemoteError(resp)
+			resp.Body.Close()
+		}
+
+		// Check if we should retry
+		if policy == nil || attempt >= policy.MaxAttempts || !policy.retryable(ctx, req, resp, transportErr) {
+			return nil, err
+		}
+
+		// Compute the delay
+		delay := policy.backoff(attempt)
+		if retryAfter > delay {
+			if policy.MaxDelay != 0 && retryAfter > policy.MaxDelay {
+				return nil, err
+			}
+			delay = retryAfter
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(delay).After(deadline) {
+			call.deadlined = true
+			return nil, err
+		}
+
+		// Wait
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, errors.Wrapf(ctx.Err(), "cancelled while waiting to retry after attempt #%d failed (%v)", attempt, err)
+		case <-timer.C:
+		}
+	}
+}
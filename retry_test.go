@@ -0,0 +1,220 @@
+package navitia
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// flakyServer fails the first failures requests with the given status & Retry-After header, then succeeds with an empty places response
+type flakyServer struct {
+	failures   int32
+	status     int
+	retryAfter string
+
+	requests int32
+}
+
+func (srv *flakyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if atomic.AddInt32(&srv.requests, 1) <= srv.failures {
+		if srv.retryAfter != "" {
+			w.Header().Set("Retry-After", srv.retryAfter)
+		}
+		w.WriteHeader(srv.status)
+		fmt.Fprint(w, "<html>Service Unavailable</html>")
+		return
+	}
+	fmt.Fprint(w, `{"places": []}`)
+}
+
+func Test_Session_Retry(t *testing.T) {
+	policy := &RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    2 * time.Second,
+		Jitter:      0.5,
+	}
+
+	newSession := func(srv *flakyServer) (*Session, func()) {
+		ts := httptest.NewServer(srv)
+		s, err := NewCustom("", ts.URL, http.DefaultClient)
+		if err != nil {
+			t.Fatalf("error while creating session: %v", err)
+		}
+		s.Retry = policy
+		return s, ts.Close
+	}
+
+	t.Run("recovers", func(t *testing.T) {
+		s, closer := newSession(&flakyServer{failures: 2, status: http.StatusServiceUnavailable})
+		defer closer()
+
+		res, err := s.Places(context.Background(), PlacesRequest{Query: "test"})
+		if err != nil {
+			t.Fatalf("expected the request to succeed after retries, got %v", err)
+		}
+		if res.Attempts != 3 {
+			t.Errorf("expected 3 attempts, got %d", res.Attempts)
+		}
+	})
+
+	t.Run("gives up", func(t *testing.T) {
+		s, closer := newSession(&flakyServer{failures: 5, status: http.StatusBadGateway})
+		defer closer()
+
+		res, err := s.Places(context.Background(), PlacesRequest{Query: "test"})
+		if !errors.Is(err, ErrServer) {
+			t.Fatalf("expected a server error, got %v", err)
+		}
+		if res.Attempts != 3 {
+			t.Errorf("expected 3 attempts, got %d", res.Attempts)
+		}
+	})
+
+	t.Run("not retryable", func(t *testing.T) {
+		srv := &flakyServer{failures: 5, status: http.StatusNotFound}
+		s, closer := newSession(srv)
+		defer closer()
+
+		_, err := s.Places(context.Background(), PlacesRequest{Query: "test"})
+		if err == nil || srv.requests != 1 {
+			t.Fatalf("expected a single failed attempt, got %d attempts (error: %v)", srv.requests, err)
+		}
+	})
+
+	t.Run("retry after", func(t *testing.T) {
+		s, closer := newSession(&flakyServer{failures: 1, status: http.StatusTooManyRequests, retryAfter: "1"})
+		defer closer()
+
+		start := time.Now()
+		_, err := s.Places(context.Background(), PlacesRequest{Query: "test"})
+		if err != nil {
+			t.Fatalf("expected the request to succeed after retries, got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed < time.Second {
+			t.Errorf("expected Retry-After to be honored, retried after %v", elapsed)
+		}
+	})
+
+	t.Run("deadline", func(t *testing.T) {
+		srv := &flakyServer{failures: 5, status: http.StatusServiceUnavailable, retryAfter: "1"}
+		s, closer := newSession(srv)
+		defer closer()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		_, err := s.Places(ctx, PlacesRequest{Query: "test"})
+		if err == nil || srv.requests != 1 {
+			t.Fatalf("expected no retry past the deadline, got %d attempts (error: %v)", srv.requests, err)
+		}
+	})
+}
+
+func Test_Session_Retry_transport(t *testing.T) {
+	policy := &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}
+
+	t.Run("connection refused", func(t *testing.T) {
+		// Get an address on which nothing listens
+		ts := httptest.NewServer(http.NotFoundHandler())
+		ts.Close()
+
+		s, err := NewCustom("", ts.URL, http.DefaultClient)
+		if err != nil {
+			t.Fatalf("error while creating session: %v", err)
+		}
+		s.Retry = policy
+
+		res, err := s.Places(context.Background(), PlacesRequest{Query: "test"})
+		if err == nil || res.Attempts != 3 {
+			t.Fatalf("expected 3 failed attempts, got %d (error: %v)", res.Attempts, err)
+		}
+	})
+
+	t.Run("certificate error", func(t *testing.T) {
+		// The default client doesn't trust the test server's certificate
+		srv := &flakyServer{}
+		ts := httptest.NewTLSServer(srv)
+		defer ts.Close()
+
+		s, err := NewCustom("", ts.URL, http.DefaultClient)
+		if err != nil {
+			t.Fatalf("error while creating session: %v", err)
+		}
+		s.Retry = policy
+
+		res, err := s.Places(context.Background(), PlacesRequest{Query: "test"})
+		if err == nil || res.Attempts != 1 {
+			t.Fatalf("expected a single failed attempt, got %d (error: %v)", res.Attempts, err)
+		}
+	})
+}
+
+func Test_transient(t *testing.T) {
+	known := []struct {
+		err       error
+		transient bool
+	}{
+		{&net.OpError{Op: "read", Err: syscall.ECONNRESET}, true},
+		{&net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}, true},
+		{&url.Error{Op: "Get", URL: "http://example.com", Err: io.ErrUnexpectedEOF}, true},
+		{&net.DNSError{Err: "no such host", Name: "example.invalid", IsNotFound: true}, false},
+		{&net.DNSError{Err: "i/o timeout", Name: "example.com", IsTimeout: true}, true},
+		{errors.New("x509: certificate signed by unknown authority"), false},
+	}
+	for i, k := range known {
+		if got := transient(k.err); got != k.transient {
+			t.Errorf("case #%d (%v): expected %t, got %t", i, k.err, k.transient, got)
+		}
+	}
+}
+
+func Test_parseRetryAfter(t *testing.T) {
+	now := time.Date(2017, 4, 27, 17, 0, 0, 0, time.UTC)
+	known := map[string]time.Duration{
+		"":                              0,
+		"120":                           2 * time.Minute,
+		"Thu, 27 Apr 2017 17:00:30 GMT": 30 * time.Second,
+		"Thu, 27 Apr 2017 16:00:00 GMT": 0,
+		"soon":                          0,
+	}
+	for in, expected := range known {
+		if got := parseRetryAfter(in, now); got != expected {
+			t.Errorf("parseRetryAfter(%q): expected %v, got %v", in, expected, got)
+		}
+	}
+}
+
+func Test_RetryPolicy_backoff(t *testing.T) {
+	policy := &RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	known := map[uint]time.Duration{
+		1:  100 * time.Millisecond,
+		2:  200 * time.Millisecond,
+		4:  800 * time.Millisecond,
+		5:  time.Second,
+		80: time.Second,
+	}
+	for attempt, expected := range known {
+		if got := policy.backoff(attempt); got != expected {
+			t.Errorf("backoff(%d): expected %v, got %v", attempt, expected, got)
+		}
+	}
+
+	// With jitter, the delay stays within bounds
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		if got := policy.backoff(2); got < 100*time.Millisecond || got > 200*time.Millisecond {
+			t.Fatalf("backoff(2) with jitter: expected a delay between 100ms and 200ms, got %v", got)
+		}
+	}
+}
@@ -0,0 +1,252 @@
+package navitia
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"time"
+	"unsafe"
+
+	"github.com/aabizri/navitia/filter"
+	"github.com/aabizri/navitia/types"
+	"github.com/pkg/errors"
+)
+
+// StopSchedulesResults holds the results of a stop schedules or terminus schedules request.
+type StopSchedulesResults struct {
+	StopSchedules []types.StopSchedule
+
+	Paging Paging `json:"links"`
+
+	// Pagination describes the position of this page in the results
+	Pagination Pagination `json:"pagination"`
+
+	// Context holds the timezone of the region, in which the date times of the results are expressed
+	Context types.Context `json:"context"`
+
+	Logging `json:"-"`
+
+	session *Session
+}
+
+// Count returns the number of stop schedules available in a StopSchedulesResults
+func (ssr *StopSchedulesResults) Count() int {
+	return len(ssr.StopSchedules)
+}
+
+// UnmarshalJSON implements unmarshalling for StopSchedulesResults.
+func (ssr *StopSchedulesResults) UnmarshalJSON(b []byte) error {
+	// First let's create the analogous structure
+	// We define some of the value as pointers to the real values, allowing us to bypass copying in cases where we don't need to process the data
+	data := &struct {
+		// Pointers to the corresponding real values
+		Paging     *Paging        `json:"links"`
+		Pagination *Pagination    `json:"pagination"`
+		Context    *types.Context `json:"context"`
+
+		// Value to process
+		StopSchedules     *[]types.StopSchedule `json:"stop_schedules"`
+		TerminusSchedules *[]types.StopSchedule `json:"terminus_schedules"`
+	}{
+		Paging:     &ssr.Paging,
+		Pagination: &ssr.Pagination,
+		Context:    &ssr.Context,
+	}
+
+	// Now unmarshall the raw data into the analogous structure
+	err := json.Unmarshal(b, data)
+	if err != nil {
+		return errors.Wrap(err, "StopSchedulesResults.UnmarshalJSON: error while unmarshalling")
+	}
+
+	// Now process the values
+	switch {
+	case data.StopSchedules != nil:
+		ssr.StopSchedules = *data.StopSchedules
+	case data.TerminusSchedules != nil:
+		ssr.StopSchedules = *data.TerminusSchedules
+	}
+	// else there's nor stop schedules nor terminus schedules found
+
+	return nil
+}
+
+// RouteSchedulesResults holds the results of a route schedules request.
+type RouteSchedulesResults struct {
+	RouteSchedules []types.RouteSchedule `json:"route_schedules"`
+
+	Paging Paging `json:"links"`
+
+	// Pagination describes the position of this page in the results
+	Pagination Pagination `json:"pagination"`
+
+	// Context holds the timezone of the region, in which the date times of the results are expressed
+	Context types.Context `json:"context"`
+
+	Logging `json:"-"`
+
+	session *Session
+}
+
+// Count returns the number of route schedules available in a RouteSchedulesResults
+func (rsr *RouteSchedulesResults) Count() int {
+	return len(rsr.RouteSchedules)
+}
+
+// ScheduleRequest contains the optional parameters for a schedules request.
+type ScheduleRequest struct {
+	// From what time on do you want to see the results ?
+	From time.Time
+
+	// Maximum duration between From and the retrieved results.
+	//
+	// Default value is 24 hours
+	Duration time.Duration
+
+	// The maximum amount of schedules per page
+	Count uint
+
+	// The page to retrieve
+	StartPage uint
+
+	// The maximum amount of date times per schedule
+	ItemsPerSchedule uint
+
+	// ForbiddenURIs
+	Forbidden []types.ID
+
+	// Freshness of the data
+	Freshness types.DataFreshness
+
+	// Calendar restricts the schedules to the ones of a given calendar
+	Calendar types.ID
+
+	// Filter restricts the schedules to the objects matching it, see the filter package
+	Filter filter.Filter
+
+	// Enables GeoJSON data in the reply. GeoJSON objects can be VERY large ! >1MB.
+	Geo bool
+
+	// local is set once the date times are expressed in the region's timezone, see localQuery
+	local bool
+}
+
+// in returns the request with its date times expressed in the given location, see localQuery
+func (req ScheduleRequest) in(loc *time.Location) query {
+	if loc != nil {
+		req.From = req.From.In(loc)
+		req.local = true
+	}
+	return req
+}
+
+func (req ScheduleRequest) toURL() (url.Values, error) {
+	values := url.Values{}
+
+	if datetime := req.From; !datetime.IsZero() {
+		str := formatRequestDateTime(datetime, req.local)
+		values.Add("from_datetime", str)
+	}
+
+	if duration := req.Duration; duration != 0 {
+		values.Add("duration", strconv.FormatInt(int64(duration/time.Second), 10))
+	}
+
+	if count := req.Count; count != 0 {
+		values.Add("count", strconv.FormatUint(uint64(count), 10))
+	}
+	if page := req.StartPage; page != 0 {
+		values.Add("start_page", strconv.FormatUint(uint64(page), 10))
+	}
+	if items := req.ItemsPerSchedule; items != 0 {
+		values.Add("items_per_schedule", strconv.FormatUint(uint64(items), 10))
+	}
+
+	// Deal with the forbidden URIs
+	if forbidden := req.Forbidden; len(forbidden) != 0 {
+		magic := *(*[]string)(unsafe.Pointer(&forbidden))
+		values["forbidden_uris[]"] = magic
+	}
+
+	// Set the freshness
+	if freshness := req.Freshness; freshness != "" {
+		values.Add("data_freshness", string(freshness))
+	}
+
+	if calendar := req.Calendar; calendar != "" {
+		values.Add("calendar", string(calendar))
+	}
+
+	// Add the filter
+	if f := req.Filter; !f.Empty() {
+		if err := f.Validate(); err != nil {
+			return nil, errors.Wrap(err, "invalid filter")
+		}
+		values.Add("filter", f.String())
+	}
+
+	// Add GEO
+	if !req.Geo {
+		values.Add("disable_geojson", "true")
+	}
+
+	return values, nil
+}
+
+const (
+	stopSchedulesEndpoint     string = "stop_schedules"
+	routeSchedulesEndpoint           = "route_schedules"
+	terminusSchedulesEndpoint        = "terminus_schedules"
+)
+
+// scheduledTypes are the types of object on which schedules can be requested
+var scheduledTypes = []string{"stop_area", "stop_point", "line", "route"}
+
+// stopSchedules is the internal function used by StopSchedules & TerminusSchedules
+func (scope *Scope) stopSchedules(ctx context.Context, req ScheduleRequest, resource types.ID, endpoint string) (*StopSchedulesResults, error) {
+	var results = &StopSchedulesResults{session: scope.session}
+
+	// Create the URL
+	url, err := scope.objectURL(resource, scheduledTypes...)
+	if err != nil {
+		return results, err
+	}
+	url += "/" + endpoint
+
+	// Call
+	err = scope.session.request(ctx, url, req, results)
+	return results, err
+}
+
+// StopSchedules requests the stop schedules of a given stop area, stop point, line or route: the date times of the next vehicles, for each stop point & route.
+//
+// The type of the resource is inferred from its ID.
+func (scope *Scope) StopSchedules(ctx context.Context, req ScheduleRequest, resource types.ID) (*StopSchedulesResults, error) {
+	return scope.stopSchedules(ctx, req, resource, stopSchedulesEndpoint)
+}
+
+// TerminusSchedules requests the terminus schedules of a given stop area, stop point, line or route: the date times of the next vehicles, for each stop point & terminus.
+//
+// The type of the resource is inferred from its ID.
+func (scope *Scope) TerminusSchedules(ctx context.Context, req ScheduleRequest, resource types.ID) (*StopSchedulesResults, error) {
+	return scope.stopSchedules(ctx, req, resource, terminusSchedulesEndpoint)
+}
+
+// RouteSchedules requests the route schedules of a given stop area, stop point, line or route: a timetable for each route.
+//
+// The type of the resource is inferred from its ID.
+func (scope *Scope) RouteSchedules(ctx context.Context, req ScheduleRequest, resource types.ID) (*RouteSchedulesResults, error) {
+	var results = &RouteSchedulesResults{session: scope.session}
+
+	// Create the URL
+	url, err := scope.objectURL(resource, scheduledTypes...)
+	if err != nil {
+		return results, err
+	}
+	url += "/" + routeSchedulesEndpoint
+
+	// Call
+	err = scope.session.request(ctx, url, req, results)
+	return results, err
+}
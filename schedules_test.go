@@ -0,0 +1,96 @@
+package navitia
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/aabizri/navitia/types"
+)
+
+func Test_ScheduleRequest_toUrl(t *testing.T) {
+	// Declare this test to be run in parallel
+	t.Parallel()
+
+	req, err := ScheduleRequest{Geo: true}.toURL()
+	if err != nil {
+		t.Fatalf("error in ScheduleRequest.toURL: %v\n\tReceived: %#v", err, req)
+	}
+	if len(req) != 0 {
+		t.Fatalf("error in ScheduleRequest.toURL: toURL created fields for non-specified parameters\n\tReceived: %#v", req)
+	}
+}
+
+// Test_StopSchedules_Resource checks that schedules can't be requested on objects which don't have schedules
+func Test_StopSchedules_Resource(t *testing.T) {
+	// Declare this test to be run in parallel
+	t.Parallel()
+
+	scope := (&Session{APIURL: "http://localhost"}).Scope("fr-idf")
+	for _, resource := range []string{"network:OIF:439", "unknown"} {
+		url, err := scope.objectURL(types.ID(resource), scheduledTypes...)
+		if err == nil {
+			t.Errorf("expected an error for resource %s but got none, url is %s", resource, url)
+		}
+	}
+
+	url, err := scope.objectURL("stop_area:OIF:SA:59346", scheduledTypes...)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := "http://localhost/coverage/fr-idf/stop_areas/stop_area:OIF:SA:59346"; url != expected {
+		t.Errorf("expected url %s, got %s", expected, url)
+	}
+}
+
+// Test_StopSchedulesResults_Unmarshal tests unmarshalling for StopSchedulesResults.
+// As the unmarshalling is done in-house, this allows us to check that the custom UnmarshalJSON function correctly
+//
+// This launches both a "correct" and "incorrect" subtest, allowing us to test both cases.
+// 	If we expect no errors but we get one, the test fails
+//	If we expect an error but we don't get one, the test fails
+func Test_StopSchedulesResults_Unmarshal(t *testing.T) {
+	testUnmarshal(t, testData["stop_schedules"], reflect.TypeOf(StopSchedulesResults{}))
+}
+
+// Test_RouteSchedulesResults_Unmarshal tests unmarshalling for RouteSchedulesResults.
+// As the unmarshalling is done by encoding/json, this allows us to check that the input can be reliably unmarshalled into the structure we have for it.
+//
+// This launches both a "correct" and "incorrect" subtest, allowing us to test both cases.
+// 	If we expect no errors but we get one, the test fails
+//	If we expect an error but we don't get one, the test fails
+func Test_RouteSchedulesResults_Unmarshal(t *testing.T) {
+	testUnmarshal(t, testData["route_schedules"], reflect.TypeOf(RouteSchedulesResults{}))
+}
+
+// Test_StopSchedulesResults_Unmarshal_calendar checks that the times of day of calendar-based schedules are decoded, and encoded back as such
+func Test_StopSchedulesResults_Unmarshal_calendar(t *testing.T) {
+	b, ok := testData["stop_schedules"].correct["calendar.json"]
+	if !ok {
+		t.Skip("no calendar data provided, skipping...")
+	}
+
+	var res StopSchedulesResults
+	if err := json.Unmarshal(b, &res); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(res.StopSchedules) != 1 || len(res.StopSchedules[0].DateTimes) != 3 {
+		t.Fatalf("unexpected stop schedules %+v", res.StopSchedules)
+	}
+	sdt := res.StopSchedules[0].DateTimes[0]
+	if sdt.DateTime.Year() != 0 || sdt.DateTime.Format(types.TimeFormat) != "073000" {
+		t.Errorf("expected the time of day 073000, got %v", sdt.DateTime)
+	}
+
+	encoded, err := json.Marshal(sdt)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if decoded["date_time"] != "073000" {
+		t.Errorf("expected the time of day to be encoded as 073000, got %v", decoded["date_time"])
+	}
+}
@@ -0,0 +1,82 @@
+package navitia
+
+import (
+	"fmt"
+	"io"
+)
+
+// ErrResponseTooLarge is returned when a response is larger than the maximum size allowed, see Session.MaxResponseSize & Session.MaxResponseSizes
+type ErrResponseTooLarge struct {
+	// Limit is the maximum size in bytes which was exceeded
+	Limit int64
+
+	// Endpoint is the endpoint of the request, such as "coverage"
+	Endpoint string
+
+	// URL is the URL of the request
+	URL string
+}
+
+// Error satisfies the error interface
+func (err *ErrResponseTooLarge) Error() string {
+	return fmt.Sprintf("response of %s endpoint is larger than the limit of %d bytes (%s)", err.Endpoint, err.Limit, err.URL)
+}
+
+// maxResponseSize returns the maximum size of a response of the given endpoint
+//
+// The endpoint's limit takes precedence over the session's, which takes precedence over the default one.
+func (s *Session) maxResponseSize(endpoint string) int64 {
+	if size := s.MaxResponseSizes[endpoint]; size > 0 {
+		return size
+	}
+	if s.MaxResponseSize > 0 {
+		return s.MaxResponseSize
+	}
+	return maxSize
+}
+
+// sizeLimitedReader is an io.Reader failing with an ErrResponseTooLarge once more than limit bytes are read.
+//
+// Contrary to io.LimitReader, which silently truncates, it allows to report the truncation.
+type sizeLimitedReader struct {
+	r     io.Reader
+	limit int64
+	read  int64
+	err   *ErrResponseTooLarge
+}
+
+// newSizeLimitedReader creates a sizeLimitedReader, the endpoint & url being reported in the error
+func newSizeLimitedReader(r io.Reader, limit int64, endpoint string, url string) *sizeLimitedReader {
+	return &sizeLimitedReader{
+		// Read one more byte than the limit, to know if it is exceeded
+		r:     io.LimitReader(r, limit+1),
+		limit: limit,
+		err:   &ErrResponseTooLarge{Limit: limit, Endpoint: endpoint, URL: url},
+	}
+}
+
+// Read satisfies io.Reader
+func (lr *sizeLimitedReader) Read(p []byte) (int, error) {
+	n, err := lr.r.Read(p)
+	lr.read += int64(n)
+	if lr.read > lr.limit {
+		return n - int(lr.read-lr.limit), lr.err
+	}
+	return n, err
+}
+
+// exceeded returns the ErrResponseTooLarge if the limit was exceeded, nil otherwise
+func (lr *sizeLimitedReader) exceeded() error {
+	if lr.read > lr.limit {
+		return lr.err
+	}
+	return nil
+}
+
+// size returns the number of bytes read, up to the limit
+func (lr *sizeLimitedReader) size() int64 {
+	if lr.read > lr.limit {
+		return lr.limit
+	}
+	return lr.read
+}
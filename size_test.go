@@ -0,0 +1,49 @@
+package navitia
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func Test_Session_MaxResponseSize(t *testing.T) {
+	body := `{"places": [], "regions": [], "padding": "` + strings.Repeat("x", 1000) + `"}`
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Flushing before writing the body prevents the Content-Length from being set, so that the limit is enforced while reading
+		if strings.Contains(r.URL.RawQuery, "chunked") {
+			w.(http.Flusher).Flush()
+		}
+		fmt.Fprint(w, body)
+	}))
+	defer ts.Close()
+
+	s, err := NewSession("", WithBaseURL(ts.URL), WithMaxResponseSize(500), WithEndpointMaxResponseSize(regionEndpoint, 5000))
+	if err != nil {
+		t.Fatalf("error while creating session: %v", err)
+	}
+	ctx := context.Background()
+
+	// Places are limited by the session-wide limit, whether the Content-Length is known or not
+	for _, query := range []string{"known", "chunked"} {
+		_, err = s.Places(ctx, PlacesRequest{Query: query})
+
+		var tooLarge *ErrResponseTooLarge
+		if !errors.As(err, &tooLarge) {
+			t.Fatalf("%s: expected an ErrResponseTooLarge, got %v", query, err)
+		}
+		if tooLarge.Limit != 500 || tooLarge.Endpoint != placesEndpoint {
+			t.Errorf("%s: unexpected error: %#v", query, tooLarge)
+		}
+	}
+
+	// Regions have their own limit
+	_, err = s.Regions(ctx, RegionRequest{})
+	if err != nil {
+		t.Errorf("expected the endpoint limit to take precedence, got %v", err)
+	}
+}
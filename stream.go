@@ -0,0 +1,224 @@
+package navitia
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/aabizri/navitia/types"
+	"github.com/pkg/errors"
+)
+
+// A streamDecoder decodes a response, emitting each element of one of its arrays as soon as it is decoded, instead of keeping them all in memory.
+type streamDecoder struct {
+	// key is the key of the streamed array, such as "journeys"
+	key string
+
+	// emit decodes the next element of the array and emits it
+	// Errors returned by the callback must be wrapped in a streamCallbackError
+	emit func(dec *json.Decoder) error
+}
+
+// streamCallbackError wraps an error returned by a streaming callback, so that it is returned as is
+type streamCallbackError struct {
+	err error
+}
+
+// Error satisfies the error interface
+func (err streamCallbackError) Error() string {
+	return err.err.Error()
+}
+
+// decode decodes the JSON object read by dec, streaming the array under the key, and unmarshalling the other values into res
+func (sd *streamDecoder) decode(dec *json.Decoder, res interface{}) error {
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+
+	// The other values are collected to be unmarshalled into res
+	rest := make(map[string]json.RawMessage)
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return errors.Wrap(err, "error while reading key")
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return errors.Errorf("expected a key, got %v", tok)
+		}
+
+		// Keep the other values
+		if !strings.EqualFold(key, sd.key) {
+			var raw json.RawMessage
+			if err := dec.Decode(&raw); err != nil {
+				return errors.Wrapf(err, "error while reading value of %s", key)
+			}
+			rest[key] = raw
+			continue
+		}
+
+		// Stream the array
+		tok, err = dec.Token()
+		if err != nil {
+			return errors.Wrapf(err, "error while reading %s", key)
+		}
+		if tok == nil {
+			continue
+		}
+		if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+			return errors.Errorf("expected %s to be an array, got %v", key, tok)
+		}
+		for dec.More() {
+			if err := sd.emit(dec); err != nil {
+				return err
+			}
+		}
+		if err := expectDelim(dec, ']'); err != nil {
+			return err
+		}
+	}
+
+	if err := expectDelim(dec, '}'); err != nil {
+		return err
+	}
+
+	// Unmarshal the other values
+	b, err := json.Marshal(rest)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, res)
+}
+
+// expectDelim reads the next token, checking it is the given delimiter
+func expectDelim(dec *json.Decoder, expected json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return errors.Wrapf(err, "error while reading %v", expected)
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != expected {
+		return errors.Errorf("expected %v, got %v", expected, tok)
+	}
+	return nil
+}
+
+// requestStream does a request like request, streaming the decoding of the results
+func (s *Session) requestStream(ctx context.Context, baseURL string, query query, res results, stream *streamDecoder) error {
+	return s.call(ctx, &Call{URL: baseURL, Request: query, Results: res, stream: stream})
+}
+
+// JourneysStream computes a list of journeys like Journeys, calling fn with each journey as soon as it is decoded.
+// The returned results hold everything but the journeys, and streamed responses aren't cached.
+// As the context of the response comes after the journeys, they are only expressed in the region's timezone if it is already known, see Scope.Location.
+//
+// If fn returns an error, the decoding stops and that error is returned.
+func (s *Session) JourneysStream(ctx context.Context, req JourneyRequest, fn func(types.Journey) error) (*JourneyResults, error) {
+	// Create the URL
+	url := s.APIURL + "/" + journeysEndpoint
+
+	// Call
+	return s.journeysStream(ctx, url, req, fn)
+}
+
+// JourneysStream computes a list of journeys in a specific scope like Journeys, calling fn with each journey as soon as it is decoded.
+//
+// See Session.JourneysStream
+func (scope *Scope) JourneysStream(ctx context.Context, req JourneyRequest, fn func(types.Journey) error) (*JourneyResults, error) {
+	// Create the URL
+	url := scope.session.APIURL + "/coverage/" + string(scope.region) + "/" + journeysEndpoint
+
+	// Call
+	return scope.session.journeysStream(ctx, url, req, fn)
+}
+
+// journeysStream is the internal function used by JourneysStream functions
+func (s *Session) journeysStream(ctx context.Context, url string, req JourneyRequest, fn func(types.Journey) error) (*JourneyResults, error) {
+	var results = &JourneyResults{session: s}
+	stream := &streamDecoder{
+		key: "journeys",
+		emit: func(dec *json.Decoder) error {
+			var journey types.Journey
+			if err := dec.Decode(&journey); err != nil {
+				return errors.Wrap(err, "error while decoding journey")
+			}
+			journey.Localize(s.location(regionOf(url)))
+			if err := fn(journey); err != nil {
+				return streamCallbackError{err}
+			}
+			return nil
+		},
+	}
+	err := s.requestStream(ctx, url, req, results, stream)
+	return results, err
+}
+
+// RegionsStream lists the areas covered by the Navitia API like Regions, calling fn with each region as soon as it is decoded.
+// The returned results hold everything but the regions, and streamed responses aren't cached.
+//
+// If fn returns an error, the decoding stops and that error is returned.
+func (s *Session) RegionsStream(ctx context.Context, req RegionRequest, fn func(types.Region) error) (*RegionResults, error) {
+	// Create the URL
+	url := s.APIURL + "/" + regionEndpoint
+
+	// Call
+	var results = &RegionResults{session: s}
+	stream := &streamDecoder{
+		key: "regions",
+		emit: func(dec *json.Decoder) error {
+			var region types.Region
+			if err := dec.Decode(&region); err != nil {
+				return errors.Wrap(err, "error while decoding region")
+			}
+			if err := fn(region); err != nil {
+				return streamCallbackError{err}
+			}
+			return nil
+		},
+	}
+	err := s.requestStream(ctx, url, req, results, stream)
+	return results, err
+}
+
+// PlacesStream searches places like Places, calling fn with each place as soon as it is decoded.
+// Contrary to Places, the places aren't sorted by quality. The returned results hold no places, and streamed responses aren't cached.
+//
+// If fn returns an error, the decoding stops and that error is returned.
+func (s *Session) PlacesStream(ctx context.Context, req PlacesRequest, fn func(types.Container) error) (*PlacesResults, error) {
+	// Create the URL
+	url := s.APIURL + "/" + placesEndpoint
+
+	// Call
+	return s.placesStream(ctx, url, req, fn)
+}
+
+// PlacesStream searches places within a coverage like Places, calling fn with each place as soon as it is decoded.
+//
+// See Session.PlacesStream
+func (scope *Scope) PlacesStream(ctx context.Context, req PlacesRequest, fn func(types.Container) error) (*PlacesResults, error) {
+	// Create the URL
+	url := scope.session.APIURL + "/coverage/" + string(scope.region) + "/" + placesEndpoint
+
+	// Call
+	return scope.session.placesStream(ctx, url, req, fn)
+}
+
+// placesStream is the internal function used by PlacesStream functions
+func (s *Session) placesStream(ctx context.Context, url string, req PlacesRequest, fn func(types.Container) error) (*PlacesResults, error) {
+	var results = &PlacesResults{session: s}
+	stream := &streamDecoder{
+		key: "places",
+		emit: func(dec *json.Decoder) error {
+			var container types.Container
+			if err := dec.Decode(&container); err != nil {
+				return errors.Wrap(err, "error while decoding place")
+			}
+			if err := fn(container); err != nil {
+				return streamCallbackError{err}
+			}
+			return nil
+		},
+	}
+	err := s.requestStream(ctx, url, req, results, stream)
+	return results, err
+}
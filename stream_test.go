@@ -0,0 +1,123 @@
+package navitia
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aabizri/navitia/types"
+	"github.com/pkg/errors"
+)
+
+// streamServer serves the given testdata for every request
+func streamServer(datum []byte) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(datum)
+	}))
+}
+
+// Test_Session_JourneysStream checks that streaming journeys gives the same journeys & paging as decoding them all at once
+func Test_Session_JourneysStream(t *testing.T) {
+	for name, datum := range testData["journeys"].correct {
+		var expected JourneyResults
+		if err := json.Unmarshal(datum, &expected); err != nil {
+			t.Fatalf("%s: error while unmarshalling: %v", name, err)
+		}
+
+		ts := streamServer(datum)
+		s, err := NewSession("", WithBaseURL(ts.URL))
+		if err != nil {
+			t.Fatalf("error while creating session: %v", err)
+		}
+
+		var journeys []types.Journey
+		res, err := s.JourneysStream(context.Background(), JourneyRequest{}, func(j types.Journey) error {
+			journeys = append(journeys, j)
+			return nil
+		})
+		ts.Close()
+		if err != nil {
+			t.Fatalf("%s: error in JourneysStream: %v", name, err)
+		}
+
+		if len(journeys) != len(expected.Journeys) {
+			t.Errorf("%s: expected %d journeys, got %d", name, len(expected.Journeys), len(journeys))
+		}
+		for i := range journeys {
+			if i < len(expected.Journeys) && !journeys[i].Departure.Equal(expected.Journeys[i].Departure) {
+				t.Errorf("%s: journey #%d differs", name, i)
+			}
+		}
+		if res.Count() != 0 {
+			t.Errorf("%s: expected the journeys not to be kept, got %d", name, res.Count())
+		}
+		if (res.Paging.Next == "") != (expected.Paging.Next == "") {
+			t.Errorf("%s: expected the paging to be decoded", name)
+		}
+	}
+}
+
+// Test_Session_RegionsStream checks streaming regions, and stopping the decoding from the callback
+func Test_Session_RegionsStream(t *testing.T) {
+	ts := streamServer(testData["coverage"].correct["global.json"])
+	defer ts.Close()
+
+	s, err := NewSession("", WithBaseURL(ts.URL))
+	if err != nil {
+		t.Fatalf("error while creating session: %v", err)
+	}
+
+	var count int
+	_, err = s.RegionsStream(context.Background(), RegionRequest{}, func(r types.Region) error {
+		if r.ID == "" {
+			t.Errorf("region #%d has no ID", count)
+		}
+		count++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("error in RegionsStream: %v", err)
+	}
+	if count == 0 {
+		t.Fatalf("expected regions to be streamed")
+	}
+
+	// Stop after the first region
+	stop := errors.New("stop")
+	count = 0
+	_, err = s.RegionsStream(context.Background(), RegionRequest{}, func(r types.Region) error {
+		count++
+		return stop
+	})
+	if err != stop || count != 1 {
+		t.Errorf("expected the callback error after a single region, got %v after %d regions", err, count)
+	}
+}
+
+// Test_Session_PlacesStream checks streaming places
+func Test_Session_PlacesStream(t *testing.T) {
+	ts := streamServer(testData["places"].correct["a.json"])
+	defer ts.Close()
+
+	s, err := NewSession("", WithBaseURL(ts.URL))
+	if err != nil {
+		t.Fatalf("error while creating session: %v", err)
+	}
+
+	var count int
+	_, err = s.Scope("sandbox").PlacesStream(context.Background(), PlacesRequest{Query: "test"}, func(c types.Container) error {
+		if _, err := c.Object(); err != nil {
+			t.Errorf("place #%d: error while decoding object: %v", count, err)
+		}
+		count++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("error in PlacesStream: %v", err)
+	}
+	if count == 0 {
+		t.Errorf("expected places to be streamed")
+	}
+}
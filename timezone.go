@@ -0,0 +1,178 @@
+package navitia
+
+import (
+	"strings"
+	"time"
+
+	"github.com/aabizri/navitia/types"
+)
+
+// A localQuery is a query holding date times, which navitia expects to be local to the region
+type localQuery interface {
+	query
+
+	// in returns the query with its date times expressed in the given location, or as-is if it is nil
+	in(loc *time.Location) query
+}
+
+// formatRequestDateTime formats a date time of a request.
+//
+// If the request is expressed in the region's timezone, see localQuery, it is sent as a local date time.
+// Otherwise, as the timezone isn't known yet, it is sent with its offset.
+func formatRequestDateTime(t time.Time, local bool) string {
+	if local {
+		return t.Format(types.DateTimeFormat)
+	}
+	return t.Format(types.DateTimeFormat + "-0700")
+}
+
+// localize re-tags the local date times of decoded results with the timezone of their region, as navitia's date times carry no offset.
+//
+// The timezone is taken from the context of the response, and remembered for the region of the request.
+// If the response has none, the timezone remembered for the region is used, if any.
+func (s *Session) localize(url string, res results) {
+	loc := res.context().Timezone
+	region := regionOf(url)
+	if loc != nil {
+		s.remember(region, loc)
+	} else {
+		loc = s.location(region)
+	}
+
+	if loc != nil {
+		res.localize(loc)
+	}
+}
+
+// remember records the timezone of a region.
+// Regions given by coordinates, such as "2.3522;48.8566", aren't recorded.
+func (s *Session) remember(region types.ID, loc *time.Location) {
+	if region == "" || strings.Contains(string(region), ";") {
+		return
+	}
+	s.locationsMu.Lock()
+	defer s.locationsMu.Unlock()
+	if s.locations == nil {
+		s.locations = make(map[types.ID]*time.Location)
+	}
+	s.locations[region] = loc
+}
+
+// location returns the timezone of a region, or nil if it isn't known yet
+func (s *Session) location(region types.ID) *time.Location {
+	s.locationsMu.Lock()
+	defer s.locationsMu.Unlock()
+	return s.locations[region]
+}
+
+// Location returns the timezone of the scope's region, or nil if it isn't known yet.
+//
+// It is learnt from the context of the responses to the requests made in the region, by any scope of the session.
+func (scope *Scope) Location() *time.Location {
+	return scope.session.location(scope.region)
+}
+
+// The contexts & localization of every results type
+
+func (jr *JourneyResults) context() types.Context { return jr.Context }
+func (jr *JourneyResults) localize(loc *time.Location) {
+	for i := range jr.Journeys {
+		jr.Journeys[i].Localize(loc)
+	}
+}
+
+func (cr *ConnectionsResults) context() types.Context { return cr.Context }
+func (cr *ConnectionsResults) localize(loc *time.Location) {
+	for i := range cr.Connections {
+		cr.Connections[i].StopDateTime.Localize(loc)
+	}
+	for i := range cr.Disruptions {
+		cr.Disruptions[i].Localize(loc)
+	}
+}
+
+func (ir *IsochroneResults) context() types.Context { return ir.Context }
+func (ir *IsochroneResults) localize(loc *time.Location) {
+	for i := range ir.Isochrones {
+		ir.Isochrones[i].Localize(loc)
+	}
+}
+
+func (ssr *StopSchedulesResults) context() types.Context { return ssr.Context }
+func (ssr *StopSchedulesResults) localize(loc *time.Location) {
+	for i := range ssr.StopSchedules {
+		ssr.StopSchedules[i].Localize(loc)
+	}
+}
+
+func (rsr *RouteSchedulesResults) context() types.Context { return rsr.Context }
+func (rsr *RouteSchedulesResults) localize(loc *time.Location) {
+	for i := range rsr.RouteSchedules {
+		rsr.RouteSchedules[i].Localize(loc)
+	}
+}
+
+func (trr *TrafficReportsResults) context() types.Context { return trr.Context }
+func (trr *TrafficReportsResults) localize(loc *time.Location) {
+	for i := range trr.TrafficReports {
+		trr.TrafficReports[i].Localize(loc)
+	}
+	for i := range trr.Disruptions {
+		trr.Disruptions[i].Localize(loc)
+	}
+}
+
+func (lrr *LineReportsResults) context() types.Context { return lrr.Context }
+func (lrr *LineReportsResults) localize(loc *time.Location) {
+	for i := range lrr.LineReports {
+		lrr.LineReports[i].Localize(loc)
+	}
+	for i := range lrr.Disruptions {
+		lrr.Disruptions[i].Localize(loc)
+	}
+}
+
+// The following results hold no date times
+
+func (pnr *PlacesNearbyResults) context() types.Context      { return pnr.Context }
+func (pnr *PlacesNearbyResults) localize(loc *time.Location) {}
+
+func (rgr *ReverseGeocodeResults) context() types.Context      { return rgr.Context }
+func (rgr *ReverseGeocodeResults) localize(loc *time.Location) {}
+
+func (pr *PlacesResults) context() types.Context      { return pr.Context }
+func (pr *PlacesResults) localize(loc *time.Location) {}
+
+func (ptr *PTObjectsResults) context() types.Context      { return ptr.Context }
+func (ptr *PTObjectsResults) localize(loc *time.Location) {}
+
+func (lr *LinesResults) context() types.Context      { return lr.Context }
+func (lr *LinesResults) localize(loc *time.Location) {}
+
+func (rr *RoutesResults) context() types.Context      { return rr.Context }
+func (rr *RoutesResults) localize(loc *time.Location) {}
+
+func (sar *StopAreasResults) context() types.Context      { return sar.Context }
+func (sar *StopAreasResults) localize(loc *time.Location) {}
+
+func (spr *StopPointsResults) context() types.Context      { return spr.Context }
+func (spr *StopPointsResults) localize(loc *time.Location) {}
+
+func (nr *NetworksResults) context() types.Context      { return nr.Context }
+func (nr *NetworksResults) localize(loc *time.Location) {}
+
+func (cr *CompaniesResults) context() types.Context      { return cr.Context }
+func (cr *CompaniesResults) localize(loc *time.Location) {}
+
+func (pmr *PhysicalModesResults) context() types.Context      { return pmr.Context }
+func (pmr *PhysicalModesResults) localize(loc *time.Location) {}
+
+func (cmr *CommercialModesResults) context() types.Context      { return cmr.Context }
+func (cmr *CommercialModesResults) localize(loc *time.Location) {}
+
+func (vjr *VehicleJourneysResults) context() types.Context      { return vjr.Context }
+func (vjr *VehicleJourneysResults) localize(loc *time.Location) {}
+
+// The regions have their own timezones, so the context of the response isn't used
+func (rr *RegionResults) context() types.Context      { return types.Context{} }
+func (rr *RegionResults) localize(loc *time.Location) {}
@@ -0,0 +1,102 @@
+package navitia
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aabizri/navitia/types"
+)
+
+func Test_Session_localize(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skipf("timezone database unavailable: %v", err)
+	}
+
+	var requested []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.Query().Get("datetime"))
+		fmt.Fprint(w, `{"journeys": [{"departure_date_time": "20171010T120000"}], "context": {"timezone": "Europe/Paris", "current_datetime": "20171010T115500"}}`)
+	}))
+	defer ts.Close()
+
+	session, err := NewSession("", WithBaseURL(ts.URL))
+	if err != nil {
+		t.Fatalf("error while creating session: %v", err)
+	}
+	scope := session.Scope("fr-idf")
+	if loc := scope.Location(); loc != nil {
+		t.Fatalf("expected an unknown timezone, got %v", loc)
+	}
+
+	// The results are in the region's timezone
+	date := time.Date(2017, 10, 10, 10, 0, 0, 0, time.UTC)
+	res, err := scope.Journeys(context.Background(), JourneyRequest{From: "a", Date: date})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := time.Date(2017, 10, 10, 12, 0, 0, 0, paris); !res.Journeys[0].Departure.Equal(expected) || res.Journeys[0].Departure.Location().String() != paris.String() {
+		t.Errorf("expected departure %v, got %v", expected, res.Journeys[0].Departure)
+	}
+	if expected := time.Date(2017, 10, 10, 11, 55, 0, 0, paris); !res.Context.CurrentDateTime.Equal(expected) {
+		t.Errorf("expected current date time %v, got %v", expected, res.Context.CurrentDateTime)
+	}
+	if res.Created.Location().String() == paris.String() {
+		t.Errorf("the Logging shouldn't be localized")
+	}
+
+	// Now that the timezone is known, the date of the request is converted to it.
+	// Before, it was sent with its offset: both designate the same instant.
+	if loc := scope.Location(); loc == nil || loc.String() != paris.String() {
+		t.Fatalf("expected timezone %v, got %v", paris, loc)
+	}
+	_, err = scope.Journeys(context.Background(), JourneyRequest{From: "a", Date: date})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := []string{"20171010T100000+0000", "20171010T120000"}; len(requested) != 2 || requested[0] != expected[0] || requested[1] != expected[1] {
+		t.Errorf("expected requested date times %v, got %v", expected, requested)
+	}
+}
+
+// Test_localQuery checks that the date times of every request are sent local to the region if its timezone is known, and with their offset otherwise
+func Test_localQuery(t *testing.T) {
+	date := time.Date(2017, 10, 10, 10, 0, 0, 0, time.UTC)
+	plus2 := time.FixedZone("", 2*60*60)
+
+	queries := map[string]localQuery{
+		"datetime":      ConnectionsRequest{From: date},
+		"from_datetime": ScheduleRequest{From: date},
+		"since":         ReportsRequest{Since: date},
+	}
+	for key, lq := range queries {
+		for loc, expected := range map[*time.Location]string{nil: "20171010T100000+0000", plus2: "20171010T120000"} {
+			values, err := lq.in(loc).toURL()
+			if err != nil {
+				t.Fatalf("%T: unexpected error: %v", lq, err)
+			}
+			if got := values.Get(key); got != expected {
+				t.Errorf("%T in %v: expected %s=%s, got %q", lq, loc, key, expected, got)
+			}
+		}
+	}
+}
+
+func Test_regionOf(t *testing.T) {
+	tests := map[string]types.ID{
+		"https://api.navitia.io/v1/coverage/fr-idf/journeys":                     "fr-idf",
+		"https://api.navitia.io/v1/coverage/fr-idf":                              "fr-idf",
+		"https://api.navitia.io/v1/coverage/2.3;48.8/coords/2.3;48.8/departures": "2.3;48.8",
+		"https://api.navitia.io/v1/journeys":                                     "",
+		"https://api.navitia.io/v1/coverage":                                     "",
+	}
+	for url, expected := range tests {
+		if got := regionOf(url); got != expected {
+			t.Errorf("regionOf(%q): expected %q, got %q", url, expected, got)
+		}
+	}
+}
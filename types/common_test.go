@@ -1,6 +1,7 @@
 package types
 
 import (
+	"bytes"
 	"encoding/json"
 	"reflect"
 	"testing"
@@ -62,3 +63,62 @@ func testUnmarshal(t *testing.T, data typeTestData, resultsType reflect.Type) {
 	t.Run("correct", sub(data.correct, true))
 	t.Run("incorrect", sub(data.incorrect, false))
 }
+
+// testMarshal is a helper to test the round-trip marshalling of any type, over the correct corpus.
+//
+// Each file is unmarshalled, marshalled back, then unmarshalled again: both values must be equal, as well as their encoding.
+// The file is first compacted & HTML-escaped as encoding/json does, as raw embedded objects are kept as-is.
+func testMarshal(t *testing.T, data typeTestData, resultsType reflect.Type) {
+	// Create the run function generator, allowing us to run this in parallel
+	rgen := func(data []byte) func(t *testing.T) {
+		return func(t *testing.T) {
+			// Declare this test to be run in parallel
+			t.Parallel()
+
+			// Normalise the input
+			var compacted, escaped bytes.Buffer
+			if err := json.Compact(&compacted, data); err != nil {
+				t.Fatalf("error while compacting input: %v", err)
+			}
+			json.HTMLEscape(&escaped, compacted.Bytes())
+
+			// Unmarshal it a first time
+			first := reflect.New(resultsType).Interface()
+			if err := json.Unmarshal(escaped.Bytes(), first); err != nil {
+				t.Fatalf("error while unmarshalling input: %v", err)
+			}
+
+			// Marshal it back, and unmarshal that
+			firstJSON, err := json.Marshal(first)
+			if err != nil {
+				t.Fatalf("error while marshalling: %v", err)
+			}
+			second := reflect.New(resultsType).Interface()
+			if err := json.Unmarshal(firstJSON, second); err != nil {
+				t.Fatalf("error while unmarshalling the marshalled value: %v\n%s", err, firstJSON)
+			}
+
+			// Compare them
+			if !reflect.DeepEqual(first, second) {
+				t.Errorf("round-trip changed the value:\nbefore: %#v\nafter: %#v", first, second)
+			}
+			secondJSON, err := json.Marshal(second)
+			if err != nil {
+				t.Fatalf("error while marshalling the round-tripped value: %v", err)
+			}
+			if !bytes.Equal(firstJSON, secondJSON) {
+				t.Errorf("round-trip changed the encoding:\nbefore: %s\nafter: %s", firstJSON, secondJSON)
+			}
+		}
+	}
+
+	// If we have no data, we skip
+	if len(data.correct) == 0 {
+		t.Skip("no data provided, skipping...")
+	}
+
+	// For all files provided
+	for name, datum := range data.correct {
+		t.Run(name, rgen(datum))
+	}
+}
@@ -65,6 +65,9 @@ type Container struct {
 	EmbeddedType string
 	Quality      int
 
+	// Distance is the distance in meters between the object and the requested coordinates, for places nearby
+	Distance uint
+
 	embeddedJSON json.RawMessage
 
 	// embeddedObject acts as a cache, it is the only element guarded by the RWMutex
@@ -124,7 +127,7 @@ func (err ErrInvalidContainer) Error() string {
 
 // Empty returns true if the container is empty (zero value)
 func (c *Container) Empty() bool {
-	return c.ID == "" && c.Name == "" && c.EmbeddedType == "" && c.Quality == 0 && len(c.embeddedJSON) == 0 && c.embeddedObject == nil
+	return c.ID == "" && c.Name == "" && c.EmbeddedType == "" && c.Quality == 0 && c.Distance == 0 && len(c.embeddedJSON) == 0 && c.embeddedObject == nil
 }
 
 // Check checks the validity of the Container. Returns an ErrInvalidContainer.
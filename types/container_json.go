@@ -2,6 +2,8 @@ package types
 
 import (
 	"encoding/json"
+	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/pkg/errors"
@@ -48,6 +50,16 @@ func (c *Container) UnmarshalJSON(b []byte) error {
 		}
 	}
 
+	if distance, ok := data["distance"]; ok {
+		// navitia sends the distance as a string, but let's accept numbers too
+		str := strings.Trim(string(distance), `"`)
+		d, err := strconv.ParseUint(str, 10, 0)
+		if err != nil {
+			return gen.err(err, "Distance", "distance", distance, "error while parsing")
+		}
+		c.Distance = uint(d)
+	}
+
 	// Now, assign the embedded content to the Container
 	if embedded, ok := data[c.EmbeddedType]; ok {
 		c.embeddedJSON = embedded
@@ -55,3 +67,52 @@ func (c *Container) UnmarshalJSON(b []byte) error {
 
 	return nil
 }
+
+// MarshalJSON satisfies the json.Marshaller interface
+//
+// The embedded object is encoded under its embedded type, as received if it wasn't decoded through Object.
+func (c Container) MarshalJSON() ([]byte, error) {
+	// Create the map to be encoded
+	data := make(map[string]interface{}, 6)
+	if c.ID != "" {
+		data["id"] = c.ID
+	}
+	if c.Name != "" {
+		data["name"] = c.Name
+	}
+	if c.EmbeddedType != "" {
+		data["embedded_type"] = c.EmbeddedType
+	}
+	if c.Quality != 0 {
+		data["quality"] = c.Quality
+	}
+	if c.Distance != 0 {
+		// navitia sends the distance as a string
+		data["distance"] = strconv.FormatUint(uint64(c.Distance), 10)
+	}
+
+	// Now add the embedded content, preferring the decoded object as it may have been modified
+	var obj Object
+	if c.mu != nil {
+		c.mu.RLock()
+		obj = c.embeddedObject
+		c.mu.RUnlock()
+	}
+	switch {
+	case c.EmbeddedType == "":
+	case obj != nil:
+		data[c.EmbeddedType] = obj
+	case len(c.embeddedJSON) != 0:
+		data[c.EmbeddedType] = c.embeddedJSON
+	}
+
+	return json.Marshal(data)
+}
+
+// optionalContainer returns a pointer to the container to be encoded, or nil if it is empty, as navitia omits such containers
+func optionalContainer(c Container) *Container {
+	if c.ID == "" && c.Name == "" && c.EmbeddedType == "" && c.Quality == 0 && c.Distance == 0 {
+		return nil
+	}
+	return &c
+}
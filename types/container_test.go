@@ -3,6 +3,7 @@ package types
 import (
 	"encoding/json"
 	"fmt"
+	"reflect"
 	"testing"
 )
 
@@ -94,6 +95,56 @@ func TestContainer_Check_NoCompare(t *testing.T) {
 	}
 }
 
+// TestContainer_MarshalJSON_Object tests that a Container keeps its embedded object when re-encoded after it has been decoded through Container.Object
+func TestContainer_MarshalJSON_Object(t *testing.T) {
+	// Get the input
+	data := testData["container"].correct
+	if len(data) == 0 {
+		t.Skip("No data to test")
+	}
+
+	// Create the run function generator, allowing us to run it in parallel
+	rgen := func(datum []byte) func(t *testing.T) {
+		return func(t *testing.T) {
+			// Decode the container & its object
+			var c = &Container{}
+			err := json.Unmarshal(datum, c)
+			if err != nil {
+				t.Fatalf("Error while unmarshalling: %v", err)
+			}
+			obj, err := c.Object()
+			if err != nil {
+				t.Fatalf("Error while calling .Object(): %v", err)
+			}
+
+			// Re-encode it, and decode it again
+			b, err := json.Marshal(c)
+			if err != nil {
+				t.Fatalf("Error while marshalling: %v", err)
+			}
+			var got = &Container{}
+			err = json.Unmarshal(b, got)
+			if err != nil {
+				t.Fatalf("Error while unmarshalling the marshalled container: %v", err)
+			}
+			gotObj, err := got.Object()
+			if err != nil {
+				t.Fatalf("Error while calling .Object() on the marshalled container: %v", err)
+			}
+
+			// Compare the objects
+			if !reflect.DeepEqual(obj, gotObj) {
+				t.Errorf("Embedded object changed:\nbefore: %#v\nafter: %#v", obj, gotObj)
+			}
+		}
+	}
+
+	// For each of them, let's run a subtest
+	for name, datum := range data {
+		t.Run(name, rgen(datum))
+	}
+}
+
 // BenchmarkContainer_UnmarshalJSON benchmarks Container.UnmarshalJSON through benchmarks
 func BenchmarkContainer_UnmarshalJSON(b *testing.B) {
 	// Get the bench data
@@ -237,6 +288,9 @@ func TestContainer_Empty(t *testing.T) {
 		{
 			Quality: 10,
 		},
+		{
+			Distance: 150,
+		},
 		{
 			embeddedObject: new(Object),
 		},
@@ -257,3 +311,32 @@ func TestContainer_Empty(t *testing.T) {
 		}
 	}
 }
+
+// TestContainer_UnmarshalJSON_Distance checks that the distance is parsed, whether it is given as a string or as a number
+func TestContainer_UnmarshalJSON_Distance(t *testing.T) {
+	inputs := []string{
+		`{"id": "stop_area:RAT:SA:GDLYO", "embedded_type": "stop_area", "distance": "150"}`,
+		`{"id": "stop_area:RAT:SA:GDLYO", "embedded_type": "stop_area", "distance": 150}`,
+	}
+	for _, in := range inputs {
+		var c = &Container{}
+		err := c.UnmarshalJSON([]byte(in))
+		if err != nil {
+			t.Fatalf("Error while unmarshalling %s: %v", in, err)
+		}
+		if c.Distance != 150 {
+			t.Errorf("Expected a distance of 150 for %s, got %d", in, c.Distance)
+		}
+	}
+
+	var c = &Container{}
+	err := c.UnmarshalJSON([]byte(`{"id": "stop_area:RAT:SA:GDLYO", "distance": "far"}`))
+	if err == nil {
+		t.Errorf("Expected an error for an invalid distance, got none")
+	}
+}
+
+// Test_Container_MarshalJSON tests that a Container survives a round-trip through its JSON encoding, over the correct corpus.
+func Test_Container_MarshalJSON(t *testing.T) {
+	testMarshal(t, testData["container"], reflect.TypeOf(Container{}))
+}
@@ -0,0 +1,128 @@
+package types
+
+import "time"
+
+// A Context is the context of a response, as sent by navitia along with the results.
+//
+// See http://doc.navitia.io/#context
+type Context struct {
+	// Timezone is the timezone of the region, in which the date times of the response are expressed
+	Timezone *time.Location
+
+	// CurrentDateTime is the date time of the server when it responded, in the region's timezone
+	CurrentDateTime time.Time
+}
+
+// InLocation returns the date time having the same wall clock as t, in the given location.
+//
+// As navitia's date times carry no offset, they are decoded in UTC while they are local to the region: InLocation re-tags them with the region's timezone.
+// The zero time, and any time if the location is nil, are returned as-is.
+func InLocation(t time.Time, loc *time.Location) time.Time {
+	if t.IsZero() || loc == nil {
+		return t
+	}
+	year, month, day := t.Date()
+	hour, min, sec := t.Clock()
+	return time.Date(year, month, day, hour, min, sec, t.Nanosecond(), loc)
+}
+
+// Localize re-tags the local date times of a Journey with the given location, see InLocation
+func (j *Journey) Localize(loc *time.Location) {
+	j.Departure = InLocation(j.Departure, loc)
+	j.Requested = InLocation(j.Requested, loc)
+	j.Arrival = InLocation(j.Arrival, loc)
+	for i := range j.Sections {
+		j.Sections[i].Localize(loc)
+	}
+}
+
+// Localize re-tags the local date times of a Section with the given location, see InLocation
+func (s *Section) Localize(loc *time.Location) {
+	s.Departure = InLocation(s.Departure, loc)
+	s.Arrival = InLocation(s.Arrival, loc)
+	for i := range s.StopTimes {
+		s.StopTimes[i].PTDateTime.Localize(loc)
+	}
+}
+
+// Localize re-tags the date times of a PTDateTime with the given location, see InLocation
+func (ptdt *PTDateTime) Localize(loc *time.Location) {
+	ptdt.Departure = InLocation(ptdt.Departure, loc)
+	ptdt.Arrival = InLocation(ptdt.Arrival, loc)
+}
+
+// Localize re-tags the date times of a StopDateTime with the given location, see InLocation
+func (sdt *StopDateTime) Localize(loc *time.Location) {
+	sdt.Departure = InLocation(sdt.Departure, loc)
+	sdt.Arrival = InLocation(sdt.Arrival, loc)
+	sdt.BaseDeparture = InLocation(sdt.BaseDeparture, loc)
+	sdt.BaseArrival = InLocation(sdt.BaseArrival, loc)
+}
+
+// Localize re-tags the date times of a ScheduleDateTime with the given location, see InLocation
+func (sdt *ScheduleDateTime) Localize(loc *time.Location) {
+	sdt.DateTime = InLocation(sdt.DateTime, loc)
+	sdt.BaseDateTime = InLocation(sdt.BaseDateTime, loc)
+}
+
+// Localize re-tags the date times of a StopSchedule with the given location, see InLocation
+func (ss *StopSchedule) Localize(loc *time.Location) {
+	for i := range ss.DateTimes {
+		ss.DateTimes[i].Localize(loc)
+	}
+	ss.First.Localize(loc)
+	ss.Last.Localize(loc)
+}
+
+// Localize re-tags the date times of a RouteSchedule with the given location, see InLocation
+func (rs *RouteSchedule) Localize(loc *time.Location) {
+	for i := range rs.Table.Rows {
+		row := &rs.Table.Rows[i]
+		for j := range row.DateTimes {
+			row.DateTimes[j].Localize(loc)
+		}
+	}
+}
+
+// Localize re-tags the local date times of an Isochrone with the given location, see InLocation
+func (i *Isochrone) Localize(loc *time.Location) {
+	i.Requested = InLocation(i.Requested, loc)
+	i.MinDateTime = InLocation(i.MinDateTime, loc)
+	i.MaxDateTime = InLocation(i.MaxDateTime, loc)
+}
+
+// Localize re-tags the application periods of a Disruption with the given location, see InLocation
+//
+// LastUpdated isn't, as navitia sends it in UTC.
+func (d *Disruption) Localize(loc *time.Location) {
+	for i := range d.Periods {
+		d.Periods[i].Begin = InLocation(d.Periods[i].Begin, loc)
+		d.Periods[i].End = InLocation(d.Periods[i].End, loc)
+	}
+}
+
+// Localize re-tags the disruptions of a TrafficReport with the given location, see Disruption.Localize
+func (tr *TrafficReport) Localize(loc *time.Location) {
+	localizeDisruptions(tr.Disruptions, loc)
+	for i := range tr.Lines {
+		localizeDisruptions(tr.Lines[i].Disruptions, loc)
+	}
+	for i := range tr.StopAreas {
+		localizeDisruptions(tr.StopAreas[i].Disruptions, loc)
+	}
+}
+
+// Localize re-tags the disruptions of a LineReport with the given location, see Disruption.Localize
+func (lr *LineReport) Localize(loc *time.Location) {
+	localizeDisruptions(lr.Disruptions, loc)
+	for i := range lr.PTObjects {
+		localizeDisruptions(lr.PTObjects[i].Disruptions, loc)
+	}
+}
+
+// localizeDisruptions localizes each of the given disruptions
+func localizeDisruptions(disruptions []Disruption, loc *time.Location) {
+	for i := range disruptions {
+		disruptions[i].Localize(loc)
+	}
+}
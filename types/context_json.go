@@ -0,0 +1,57 @@
+package types
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// UnmarshalJSON implements json.Unmarshaller for a Context
+func (c *Context) UnmarshalJSON(b []byte) error {
+	// First let's create the analogous structure
+	data := &struct {
+		Timezone        string `json:"timezone"`
+		CurrentDateTime string `json:"current_datetime"`
+	}{}
+
+	// Now unmarshall the raw data into the analogous structure
+	err := json.Unmarshal(b, data)
+	if err != nil {
+		return errors.Wrap(err, "Error while unmarshalling Context")
+	}
+
+	// Create the error generator
+	gen := unmarshalErrorMaker{"Context", b}
+
+	// Load the timezone
+	if data.Timezone != "" {
+		c.Timezone, err = time.LoadLocation(data.Timezone)
+		if err != nil {
+			return gen.err(err, "Timezone", "timezone", data.Timezone, "time.LoadLocation failed")
+		}
+	}
+
+	// The current date time is expressed in that timezone
+	current, err := parseDateTime(data.CurrentDateTime)
+	if err != nil {
+		return gen.err(err, "CurrentDateTime", "current_datetime", data.CurrentDateTime, "parseDateTime failed")
+	}
+	c.CurrentDateTime = InLocation(current, c.Timezone)
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaller for a Context
+func (c Context) MarshalJSON() ([]byte, error) {
+	data := struct {
+		Timezone        string `json:"timezone,omitempty"`
+		CurrentDateTime string `json:"current_datetime,omitempty"`
+	}{
+		CurrentDateTime: formatDateTime(c.CurrentDateTime),
+	}
+	if c.Timezone != nil {
+		data.Timezone = c.Timezone.String()
+	}
+	return json.Marshal(data)
+}
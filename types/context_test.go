@@ -0,0 +1,90 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func Test_Context_UnmarshalJSON(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skipf("timezone database unavailable: %v", err)
+	}
+
+	var c Context
+	err = json.Unmarshal([]byte(`{"timezone": "Europe/Paris", "current_datetime": "20171010T115500"}`), &c)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.Timezone.String() != paris.String() {
+		t.Errorf("expected timezone %v, got %v", paris, c.Timezone)
+	}
+	if expected := time.Date(2017, 10, 10, 11, 55, 0, 0, paris); !c.CurrentDateTime.Equal(expected) {
+		t.Errorf("expected current date time %v, got %v", expected, c.CurrentDateTime)
+	}
+
+	// An unknown timezone is an error
+	err = json.Unmarshal([]byte(`{"timezone": "Nowhere/Atlantis"}`), &c)
+	if err == nil {
+		t.Errorf("expected an error for an unknown timezone")
+	}
+}
+
+func Test_Journey_Localize(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skipf("timezone database unavailable: %v", err)
+	}
+
+	// Use the journeys of the corpus
+	data := testData["journey"].correct
+	if len(data) == 0 {
+		t.Skip("no data provided, skipping...")
+	}
+	for name, datum := range data {
+		var j Journey
+		if err := json.Unmarshal(datum, &j); err != nil {
+			t.Fatalf("%s: error while unmarshalling: %v", name, err)
+		}
+		departure := j.Departure
+		j.Localize(paris)
+
+		// The wall clock is kept, but in the given location
+		if j.Departure.Location().String() != paris.String() || j.Departure.Format(DateTimeFormat) != departure.Format(DateTimeFormat) {
+			t.Errorf("%s: expected departure %s in %v, got %v", name, departure.Format(DateTimeFormat), paris, j.Departure)
+		}
+		for i, s := range j.Sections {
+			if !s.Departure.IsZero() && s.Departure.Location().String() != paris.String() {
+				t.Errorf("%s: section %d departure isn't localized: %v", name, i, s.Departure)
+			}
+			for k, st := range s.StopTimes {
+				if !st.PTDateTime.Arrival.IsZero() && st.PTDateTime.Arrival.Location().String() != paris.String() {
+					t.Errorf("%s: section %d stop time %d arrival isn't localized: %v", name, i, k, st.PTDateTime.Arrival)
+				}
+			}
+		}
+	}
+}
+
+func Test_Disruption_Localize(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skipf("timezone database unavailable: %v", err)
+	}
+
+	var d Disruption
+	err = json.Unmarshal([]byte(`{"id": "d1", "updated_at": "20171010T100000", "application_periods": [{"begin": "20171010T120000", "end": "20171010T180000"}]}`), &d)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	d.Localize(paris)
+
+	// The application periods are local, but not the update date time
+	if begin := d.Periods[0].Begin; begin.Location().String() != paris.String() || begin.Format(DateTimeFormat) != "20171010T120000" {
+		t.Errorf("expected the period to begin at 20171010T120000 in %v, got %v", paris, begin)
+	}
+	if d.LastUpdated.Location() != time.UTC {
+		t.Errorf("expected the update date time to be kept in UTC, got %v", d.LastUpdated)
+	}
+}
@@ -36,3 +36,17 @@ func (c *Coordinates) UnmarshalJSON(b []byte) error {
 
 	return nil
 }
+
+// MarshalJSON implements json.Marshaller for a Coordinates
+//
+// As navitia does, the latitude & longitude are encoded as strings.
+func (c Coordinates) MarshalJSON() ([]byte, error) {
+	data := struct {
+		Latitude  string `json:"lat"`
+		Longitude string `json:"lon"`
+	}{
+		Latitude:  strconv.FormatFloat(c.Latitude, 'f', -1, 64),
+		Longitude: strconv.FormatFloat(c.Longitude, 'f', -1, 64),
+	}
+	return json.Marshal(data)
+}
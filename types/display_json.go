@@ -65,3 +65,33 @@ func (d *Display) UnmarshalJSON(b []byte) error {
 
 	return nil
 }
+
+// MarshalJSON implements json.Marshaller for a Display
+func (d Display) MarshalJSON() ([]byte, error) {
+	data := struct {
+		Headsign       string      `json:"headsign,omitempty"`
+		Network        string      `json:"network,omitempty"`
+		Direction      string      `json:"direction,omitempty"`
+		CommercialMode ID          `json:"commercial_mode,omitempty"`
+		PhysicalMode   ID          `json:"physical_mode,omitempty"`
+		Label          string      `json:"label,omitempty"`
+		Code           string      `json:"code,omitempty"`
+		Description    string      `json:"description,omitempty"`
+		Equipments     []Equipment `json:"equipments"`
+		Color          string      `json:"color,omitempty"`
+		TextColor      string      `json:"text_color,omitempty"`
+	}{
+		Headsign:       d.Headsign,
+		Network:        d.Network,
+		Direction:      d.Direction,
+		CommercialMode: d.CommercialMode,
+		PhysicalMode:   d.PhysicalMode,
+		Label:          d.Label,
+		Code:           d.Code,
+		Description:    d.Description,
+		Equipments:     d.Equipments,
+		Color:          formatColor(d.Color),
+		TextColor:      formatColor(d.TextColor),
+	}
+	return json.Marshal(data)
+}
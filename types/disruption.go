@@ -128,26 +128,26 @@ type ImpactedSection struct {
 // An ImpactedStop records the impact to a stop
 type ImpactedStop struct {
 	// The impacted stop point of the trip
-	Point StopPoint
+	Point StopPoint `json:"stop_point"`
 
 	// New departure hour (format HHMMSS) of the trip on this stop point
-	NewDeparture string
+	NewDeparture string `json:"amended_departure_time"`
 
 	// New arrival hour (format HHMMSS) of the trip on this stop point
-	NewArrival string
+	NewArrival string `json:"amended_arrival_time"`
 
 	// Base departure hour (format HHMMSS) of the trip on this stop point
-	BaseDeparture string
+	BaseDeparture string `json:"base_departure_time"`
 
 	// Base arrival hour (format HHMMSS) of the trip on this stop point
-	BaseArrival string
+	BaseArrival string `json:"base_arrival_time"`
 
 	// Cause of the modification
-	Cause string
+	Cause string `json:"cause"`
 
 	// Effect on that StopPoint
 	// Can be "added", "deleted", "delayed"
-	Effect string
+	Effect string `json:"stop_time_effect"`
 }
 
 // Period of effect
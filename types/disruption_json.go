@@ -97,14 +97,14 @@ func (s *Severity) UnmarshalJSON(b []byte) error {
 	data := &struct {
 		// The references
 		Name     *string `json:"name"`
-		Priority *int    `json:"priority,omitempty"` // As priority can be null, and 0 is the highest priority.
+		Priority **int   `json:"priority,omitempty"` // As priority can be null, and 0 is the highest priority.
 		Effect   *Effect `json:"effect"`
 
 		// Those we will process
 		Color string `json:"color"`
 	}{
 		Name:     &s.Name,
-		Priority: s.Priority,
+		Priority: &s.Priority,
 		Effect:   &s.Effect,
 	}
 
@@ -128,3 +128,63 @@ func (s *Severity) UnmarshalJSON(b []byte) error {
 
 	return nil
 }
+
+// MarshalJSON implements json.Marshaller for a Disruption
+func (d Disruption) MarshalJSON() ([]byte, error) {
+	data := struct {
+		ID                ID               `json:"id"`
+		Status            string           `json:"status,omitempty"`
+		InputDisruptionID ID               `json:"disruption_id,omitempty"`
+		InputImpactID     ID               `json:"impact_id,omitempty"`
+		Severity          Severity         `json:"severity"`
+		Periods           []Period         `json:"application_periods"`
+		Messages          []Message        `json:"messages"`
+		Impacted          []ImpactedObject `json:"impacted_objects"`
+		Cause             string           `json:"cause,omitempty"`
+		Category          string           `json:"category,omitempty"`
+		LastUpdated       string           `json:"updated_at,omitempty"`
+	}{
+		ID:                d.ID,
+		Status:            d.Status,
+		InputDisruptionID: d.InputDisruptionID,
+		InputImpactID:     d.InputImpactID,
+		Severity:          d.Severity,
+		Periods:           d.Periods,
+		Messages:          d.Messages,
+		Impacted:          d.Impacted,
+		Cause:             d.Cause,
+		Category:          d.Category,
+		LastUpdated:       formatDateTime(d.LastUpdated),
+	}
+	return json.Marshal(data)
+}
+
+// MarshalJSON implements json.Marshaller for a Period
+func (p Period) MarshalJSON() ([]byte, error) {
+	data := struct {
+		Begin string `json:"begin,omitempty"`
+		End   string `json:"end,omitempty"`
+	}{
+		Begin: formatDateTime(p.Begin),
+		End:   formatDateTime(p.End),
+	}
+	return json.Marshal(data)
+}
+
+// MarshalJSON implements json.Marshaller for a Severity
+//
+// A nil Priority isn't encoded.
+func (s Severity) MarshalJSON() ([]byte, error) {
+	data := struct {
+		Name     string `json:"name,omitempty"`
+		Priority *int   `json:"priority,omitempty"`
+		Effect   Effect `json:"effect,omitempty"`
+		Color    string `json:"color,omitempty"`
+	}{
+		Name:     s.Name,
+		Priority: s.Priority,
+		Effect:   s.Effect,
+		Color:    formatColor(s.Color),
+	}
+	return json.Marshal(data)
+}
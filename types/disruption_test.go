@@ -14,3 +14,8 @@ import (
 func Test_Disruption_Unmarshal(t *testing.T) {
 	testUnmarshal(t, testData["disruption"], reflect.TypeOf(Disruption{}))
 }
+
+// Test_Disruption_MarshalJSON tests that a Disruption survives a round-trip through its JSON encoding, over the correct corpus.
+func Test_Disruption_MarshalJSON(t *testing.T) {
+	testMarshal(t, testData["disruption"], reflect.TypeOf(Disruption{}))
+}
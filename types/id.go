@@ -28,11 +28,12 @@ var typeNames = map[string]bool{
 	"company":         true,
 	"admin":           true,
 	"stop_point":      true,
+	"vehicle_journey": true,
 }
 
 // Type gets the type of object this ID refers to.
 //
-// Possible types: network, line, route, stop_area, commercial_mode, physical_mode, company, admin, stop_point.
+// Possible types: network, line, route, stop_area, commercial_mode, physical_mode, company, admin, stop_point, vehicle_journey.
 //
 // This is just guessing, if no type is found, type returns an empty string.
 func (id ID) Type() string {
@@ -1,10 +1,32 @@
 package types
 
-import "github.com/paulmach/go.geojson"
+import (
+	"time"
+
+	"github.com/twpayne/go-geom"
+)
 
 // An Isochrone is sent back by the /isochrones service, it gives you a multi-polygon geojson response which represent a same time travel zone.
 //
 // See https://en.wikipedia.org/wiki/Isochrone_map for what is an isochrone.
 //
 // See http://doc.navitia.io/#isochrones-currently-in-beta
-type Isochrone geojson.Geometry
+type Isochrone struct {
+	// Geometry is the zone reachable within the duration band
+	Geometry *geom.MultiPolygon
+
+	// The duration band of this isochrone: every point in Geometry is reachable in at least MinDuration and at most MaxDuration
+	MinDuration time.Duration
+	MaxDuration time.Duration
+
+	// From & To, only one of them is filled, according to the request
+	From Container
+	To   Container
+
+	// The requested date time
+	Requested time.Time
+
+	// The earliest and latest date time of the duration band
+	MinDateTime time.Time
+	MaxDateTime time.Time
+}
@@ -0,0 +1,115 @@
+package types
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/twpayne/go-geom"
+	"github.com/twpayne/go-geom/encoding/geojson"
+)
+
+// UnmarshalJSON implements json.Unmarshaller for an Isochrone
+func (iso *Isochrone) UnmarshalJSON(b []byte) error {
+	// First let's create the analogous structure
+	// We define some of the value as pointers to the real values, allowing us to bypass copying in cases where we don't need to process the data
+	data := &struct {
+		// Pointers to the corresponding real values
+		From *Container `json:"from"`
+		To   *Container `json:"to"`
+
+		// Values to process
+		Geo         *geojson.Geometry `json:"geojson"`
+		MinDuration int64             `json:"min_duration"`
+		MaxDuration int64             `json:"max_duration"`
+		Requested   string            `json:"requested_date_time"`
+		MinDateTime string            `json:"min_date_time"`
+		MaxDateTime string            `json:"max_date_time"`
+	}{
+		From: &iso.From,
+		To:   &iso.To,
+	}
+
+	// Now unmarshall the raw data into the analogous structure
+	err := json.Unmarshal(b, data)
+	if err != nil {
+		return errors.Wrap(err, "Error while unmarshalling Isochrone")
+	}
+
+	// Create the error generator
+	gen := unmarshalErrorMaker{"Isochrone", b}
+
+	// As the given durations are in seconds, let's multiply them by one second to have the correct value
+	iso.MinDuration = time.Duration(data.MinDuration) * time.Second
+	iso.MaxDuration = time.Duration(data.MaxDuration) * time.Second
+
+	// For the date times, we use parseDateTime
+	iso.Requested, err = parseDateTime(data.Requested)
+	if err != nil {
+		return gen.err(err, "Requested", "requested_date_time", data.Requested, "parseDateTime failed")
+	}
+	iso.MinDateTime, err = parseDateTime(data.MinDateTime)
+	if err != nil {
+		return gen.err(err, "MinDateTime", "min_date_time", data.MinDateTime, "parseDateTime failed")
+	}
+	iso.MaxDateTime, err = parseDateTime(data.MaxDateTime)
+	if err != nil {
+		return gen.err(err, "MaxDateTime", "max_date_time", data.MaxDateTime, "parseDateTime failed")
+	}
+
+	// Now let's deal with the geom
+	if data.Geo != nil {
+		// Catch an error !
+		if data.Geo.Coordinates == nil {
+			return gen.err(nil, "Geometry", "geojson", data.Geo, "Geo.Coordinates is nil, can't continue as that will cause a panic")
+		}
+
+		// Let's decode it
+		geot, err := data.Geo.Decode()
+		if err != nil {
+			return gen.err(err, "Geometry", "geojson", data.Geo, "Geo.Decode() failed")
+		}
+		// And let's assert the type
+		geo, ok := geot.(*geom.MultiPolygon)
+		if !ok {
+			return gen.err(nil, "Geometry", "geojson", data.Geo, "Geo type assertion failed, expected a MultiPolygon !")
+		}
+		// Now let's assign it
+		iso.Geometry = geo
+	}
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaller for an Isochrone
+func (iso Isochrone) MarshalJSON() ([]byte, error) {
+	data := struct {
+		From        *Container        `json:"from,omitempty"`
+		To          *Container        `json:"to,omitempty"`
+		Geo         *geojson.Geometry `json:"geojson,omitempty"`
+		MinDuration int64             `json:"min_duration"`
+		MaxDuration int64             `json:"max_duration"`
+		Requested   string            `json:"requested_date_time,omitempty"`
+		MinDateTime string            `json:"min_date_time,omitempty"`
+		MaxDateTime string            `json:"max_date_time,omitempty"`
+	}{
+		From:        optionalContainer(iso.From),
+		To:          optionalContainer(iso.To),
+		MinDuration: int64(iso.MinDuration / time.Second),
+		MaxDuration: int64(iso.MaxDuration / time.Second),
+		Requested:   formatDateTime(iso.Requested),
+		MinDateTime: formatDateTime(iso.MinDateTime),
+		MaxDateTime: formatDateTime(iso.MaxDateTime),
+	}
+
+	// Encode the geometry
+	if iso.Geometry != nil {
+		geo, err := geojson.Encode(iso.Geometry)
+		if err != nil {
+			return nil, errors.Wrap(err, "Isochrone.MarshalJSON: error while encoding Geometry")
+		}
+		data.Geo = geo
+	}
+
+	return json.Marshal(data)
+}
@@ -0,0 +1,21 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Test_Isochrone_Unmarshal tests unmarshalling for Isochrone.
+// As the unmarshalling is done in-house, this allows us to check that the custom UnmarshalJSON function correctly
+//
+// This launches both a "correct" and "incorrect" subtest, allowing us to test both cases.
+// 	If we expect no errors but we get one, the test fails
+//	If we expect an error but we don't get one, the test fails
+func Test_Isochrone_Unmarshal(t *testing.T) {
+	testUnmarshal(t, testData["isochrone"], reflect.TypeOf(Isochrone{}))
+}
+
+// Test_Isochrone_MarshalJSON tests that a Isochrone survives a round-trip through its JSON encoding, over the correct corpus.
+func Test_Isochrone_MarshalJSON(t *testing.T) {
+	testMarshal(t, testData["isochrone"], reflect.TypeOf(Isochrone{}))
+}
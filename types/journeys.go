@@ -75,6 +75,9 @@ type CO2Emissions struct {
 type Fare struct {
 	Total currency.Amount
 	Found bool
+
+	// value is the decoded value of the Total, as an Amount doesn't expose it
+	value float64
 }
 
 // TravelerType is a Traveler's type
@@ -3,6 +3,7 @@ package types
 import (
 	"encoding/json"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -36,6 +37,8 @@ func (j *Journey) UnmarshalJSON(b []byte) error {
 
 		Fare *Fare `json:"fare"`
 
+		CO2Emissions *CO2Emissions `json:"co2_emission"`
+
 		Status *Effect `json:"status"`
 	}{
 		Transfers: &j.Transfers,
@@ -45,6 +48,8 @@ func (j *Journey) UnmarshalJSON(b []byte) error {
 		Type:      &j.Type,
 		Fare:      &j.Fare,
 		Status:    &j.Status,
+
+		CO2Emissions: &j.CO2Emissions,
 	}
 
 	// Now unmarshall the raw data into the analogous structure
@@ -81,11 +86,9 @@ func (f *Fare) UnmarshalJSON(b []byte) error {
 	// First let's create the analogous structure
 	// We define some of the value as pointers to the real values, allowing us to bypass copying in cases where we don't need to process the data
 	data := &struct {
-		Found *bool `json:"found"`
-		Cost  struct {
-			Value    string `json:"value"`
-			Currency string `json:"currency"`
-		} `json:"cost"`
+		Found *bool    `json:"found"`
+		Total fareCost `json:"total"`
+		Cost  fareCost `json:"cost"` // Kept for compatibility, navitia sends the total
 	}{
 		Found: &f.Found,
 	}
@@ -101,29 +104,46 @@ func (f *Fare) UnmarshalJSON(b []byte) error {
 
 	// Let's convert the cost now
 	// If we have no defined fare, let's skip that part
-	if data.Cost.Value == "" || data.Cost.Currency == "" {
+	cost := data.Total
+	if cost.Value == "" || cost.Currency == "" {
+		cost = data.Cost
+	}
+	if cost.Value == "" || cost.Currency == "" {
 		return nil
 	}
 
 	// First get the currency unit
-	unit, err := currency.ParseISO(data.Cost.Currency)
+	unit, err := currency.ParseISO(cost.Currency)
 	if err != nil {
-		return gen.err(err, "Total", "cost.currency", data.Cost.Currency, "error while retrieving currency unit via currency.ParseISO")
+		return gen.err(err, "Total", "total.currency", cost.Currency, "error while retrieving currency unit via currency.ParseISO")
+	}
+
+	// Then the value
+	value, err := strconv.ParseFloat(cost.Value, 64)
+	if err != nil {
+		return gen.err(err, "Total", "total.value", cost.Value, "error in strconv.ParseFloat")
 	}
 
 	// Now let's create the correct amount
-	f.Total = unit.Amount(data.Cost.Value)
+	f.Total = unit.Amount(value)
+	f.value = value
 
 	return nil
 }
 
+// fareCost is the cost of a Fare, as found in the Navitia api
+type fareCost struct {
+	Value    string `json:"value"`
+	Currency string `json:"currency"`
+}
+
 // UnmarshalJSON implements json.Unmarshaller for CO2Emissions
 func (c *CO2Emissions) UnmarshalJSON(b []byte) error {
 	// First let's create the analogous structure
 	// We define some of the value as pointers to the real values, allowing us to bypass copying in cases where we don't need to process the data
 	data := &struct {
-		Unit  *string `json:"unit"`
-		Value string  `json:"value"`
+		Unit  *string         `json:"unit"`
+		Value json.RawMessage `json:"value"`
 	}{
 		Unit: &c.Unit,
 	}
@@ -137,12 +157,83 @@ func (c *CO2Emissions) UnmarshalJSON(b []byte) error {
 	// Let's create the error generator
 	gen := unmarshalErrorMaker{"CO2Emissions", b}
 
-	// Now parse the value
-	f, err := strconv.ParseFloat(data.Value, 64)
+	// Now parse the value, navitia sends it as a number, but let's accept strings too
+	if len(data.Value) == 0 {
+		return nil
+	}
+	str := strings.Trim(string(data.Value), `"`)
+	f, err := strconv.ParseFloat(str, 64)
 	if err != nil {
-		return gen.err(err, "Value", "value", data.Value, "error in strconv.ParseFloat")
+		return gen.err(err, "Value", "value", str, "error in strconv.ParseFloat")
 	}
 	c.Value = f
 
 	return nil
 }
+
+// MarshalJSON implements json.Marshaller for a Journey
+func (j Journey) MarshalJSON() ([]byte, error) {
+	data := struct {
+		Duration     int64                `json:"duration"`
+		Transfers    uint                 `json:"nb_transfers"`
+		Departure    string               `json:"departure_date_time,omitempty"`
+		Requested    string               `json:"requested_date_time,omitempty"`
+		Arrival      string               `json:"arrival_date_time,omitempty"`
+		Sections     []Section            `json:"sections"`
+		From         *Container           `json:"from,omitempty"`
+		To           *Container           `json:"to,omitempty"`
+		Type         JourneyQualification `json:"type,omitempty"`
+		Fare         Fare                 `json:"fare"`
+		CO2Emissions CO2Emissions         `json:"co2_emission"`
+		Status       Effect               `json:"status,omitempty"`
+	}{
+		Duration:     int64(j.Duration / time.Second),
+		Transfers:    j.Transfers,
+		Departure:    formatDateTime(j.Departure),
+		Requested:    formatDateTime(j.Requested),
+		Arrival:      formatDateTime(j.Arrival),
+		Sections:     j.Sections,
+		From:         optionalContainer(j.From),
+		To:           optionalContainer(j.To),
+		Type:         j.Type,
+		Fare:         j.Fare,
+		CO2Emissions: j.CO2Emissions,
+		Status:       j.Status,
+	}
+	return json.Marshal(data)
+}
+
+// MarshalJSON implements json.Marshaller for a Fare
+//
+// As an Amount doesn't expose its value, only a decoded Total is encoded.
+func (f Fare) MarshalJSON() ([]byte, error) {
+	data := struct {
+		Found bool      `json:"found"`
+		Total *fareCost `json:"total,omitempty"`
+	}{
+		Found: f.Found,
+	}
+
+	// Only encode the total if it is still the decoded one
+	unit := f.Total.Currency()
+	if f.Total != (currency.Amount{}) && f.Total == unit.Amount(f.value) {
+		data.Total = &fareCost{
+			Value:    strconv.FormatFloat(f.value, 'f', -1, 64),
+			Currency: unit.String(),
+		}
+	}
+
+	return json.Marshal(data)
+}
+
+// MarshalJSON implements json.Marshaller for CO2Emissions
+func (c CO2Emissions) MarshalJSON() ([]byte, error) {
+	data := struct {
+		Unit  string  `json:"unit"`
+		Value float64 `json:"value"`
+	}{
+		Unit:  c.Unit,
+		Value: c.Value,
+	}
+	return json.Marshal(data)
+}
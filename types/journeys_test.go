@@ -1,8 +1,11 @@
 package types
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
+
+	"golang.org/x/text/currency"
 )
 
 // Test_Journey_Unmarshal tests unmarshalling for Journey.
@@ -15,6 +18,11 @@ func Test_Journey_Unmarshal(t *testing.T) {
 	testUnmarshal(t, testData["journey"], reflect.TypeOf(Journey{}))
 }
 
+// Test_Journey_MarshalJSON tests that a Journey survives a round-trip through its JSON encoding, over the correct corpus.
+func Test_Journey_MarshalJSON(t *testing.T) {
+	testMarshal(t, testData["journey"], reflect.TypeOf(Journey{}))
+}
+
 // BenchmarkJourney_UnmarshalJSON benchmarks Journey unmarshalling via subbenchmarks
 func BenchmarkJourney_UnmarshalJSON(b *testing.B) {
 	// Get the bench data
@@ -43,3 +51,50 @@ func BenchmarkJourney_UnmarshalJSON(b *testing.B) {
 		b.Run(name, runFunc)
 	}
 }
+
+// Test_Journey_Unmarshal_known tests that the fare, CO2 emissions & stop times of a Journey are decoded
+func Test_Journey_Unmarshal_known(t *testing.T) {
+	data := []byte(`{
+		"fare": {"found": true, "total": {"value": "190.0", "currency": "EUR"}},
+		"co2_emission": {"unit": "gEC", "value": 25.005},
+		"sections": [{"stop_date_times": [{"departure_date_time": "20171010T120000", "stop_point": {"id": "stop_point:a"}}]}]
+	}`)
+
+	var j Journey
+	err := json.Unmarshal(data, &j)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !j.Fare.Found || j.Fare.Total.Currency() != currency.EUR {
+		t.Errorf("unexpected fare: %+v", j.Fare)
+	}
+	if j.CO2Emissions != (CO2Emissions{Unit: "gEC", Value: 25.005}) {
+		t.Errorf("unexpected CO2 emissions: %+v", j.CO2Emissions)
+	}
+	if len(j.Sections) != 1 || len(j.Sections[0].StopTimes) != 1 {
+		t.Fatalf("unexpected sections: %+v", j.Sections)
+	}
+	st := j.Sections[0].StopTimes[0]
+	if st.StopPoint.ID != "stop_point:a" || st.PTDateTime.Departure.Format(DateTimeFormat) != "20171010T120000" {
+		t.Errorf("unexpected stop time: %+v", st)
+	}
+}
+
+// Test_Fare_MarshalJSON tests that a Fare encodes its decoded total without losing precision
+func Test_Fare_MarshalJSON(t *testing.T) {
+	var f Fare
+	err := json.Unmarshal([]byte(`{"found": true, "total": {"value": "1234.505", "currency": "JPY"}}`), &f)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	b, err := json.Marshal(f)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	const expected = `{"found":true,"total":{"value":"1234.505","currency":"JPY"}}`
+	if string(b) != expected {
+		t.Errorf("expected %s, got %s", expected, b)
+	}
+}
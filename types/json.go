@@ -1,9 +1,10 @@
-// json.go provides types & functions for json unmarshalling
+// json.go provides types & functions for json unmarshalling & marshalling
 
 package types
 
 import (
 	"fmt"
+	"image/color"
 	"strings"
 	"time"
 
@@ -15,6 +16,8 @@ const (
 	DateTimeFormat string = "20060102T150405" // YYYYMMDDThhmmss
 	// DateFormat is when there is no time info
 	DateFormat string = "20060102"
+	// TimeFormat is when there is no date info, as in calendar-based schedules
+	TimeFormat string = "150405"
 )
 
 // parseDateTime parses a time formatted under iso-date-time as indicated in the Navitia api.
@@ -42,6 +45,28 @@ func parseDateTime(datetime string) (time.Time, error) {
 	return res, err
 }
 
+// formatDateTime formats a time as indicated in the Navitia api, the inverse of parseDateTime.
+// If the given time is the zero value of time.Time, then an empty string is returned
+func formatDateTime(datetime time.Time) string {
+	if datetime.IsZero() {
+		return ""
+	}
+	return datetime.Format(DateTimeFormat)
+}
+
+// formatColor formats a color in hexadecimal as in the Navitia api, the inverse of parseColor.
+// The alpha channel is ignored, and a nil color is formatted as an empty string
+func formatColor(clr color.Color) string {
+	if clr == nil {
+		return ""
+	}
+	nrgba, ok := clr.(color.NRGBA)
+	if !ok {
+		nrgba = color.NRGBAModel.Convert(clr).(color.NRGBA)
+	}
+	return fmt.Sprintf("%02X%02X%02X", nrgba.R, nrgba.G, nrgba.B)
+}
+
 // UnmarshalError is returned when unmarshalling fails
 // It implements both error and github.com/pkg/errors's causer
 type UnmarshalError struct {
@@ -2,6 +2,7 @@ package types
 
 import (
 	"encoding/json"
+	"fmt"
 	"strconv"
 
 	"github.com/pkg/errors"
@@ -94,3 +95,38 @@ func (l *Line) UnmarshalJSON(b []byte) error {
 
 	return nil
 }
+
+// MarshalJSON implements json.Marshaller for a Line
+//
+// The opening & closing times are encoded as HHMMSS, and aren't encoded if they are zero.
+func (l Line) MarshalJSON() ([]byte, error) {
+	data := struct {
+		ID             ID             `json:"id"`
+		Name           string         `json:"name,omitempty"`
+		Code           string         `json:"code,omitempty"`
+		Color          string         `json:"color,omitempty"`
+		OpeningTime    string         `json:"opening_time,omitempty"`
+		ClosingTime    string         `json:"closing_time,omitempty"`
+		Routes         []Route        `json:"routes"`
+		CommercialMode CommercialMode `json:"commercial_mode"`
+		PhysicalModes  []PhysicalMode `json:"physical_modes"`
+	}{
+		ID:             l.ID,
+		Name:           l.Name,
+		Code:           l.Code,
+		Color:          formatColor(l.Color),
+		Routes:         l.Routes,
+		CommercialMode: l.CommercialMode,
+		PhysicalModes:  l.PhysicalModes,
+	}
+
+	// For OpeningTime and ClosingTime
+	if t := l.OpeningTime; t.Hours != 0 || t.Minutes != 0 || t.Seconds != 0 {
+		data.OpeningTime = fmt.Sprintf("%02d%02d%02d", t.Hours, t.Minutes, t.Seconds)
+	}
+	if t := l.ClosingTime; t.Hours != 0 || t.Minutes != 0 || t.Seconds != 0 {
+		data.ClosingTime = fmt.Sprintf("%02d%02d%02d", t.Hours, t.Minutes, t.Seconds)
+	}
+
+	return json.Marshal(data)
+}
@@ -15,6 +15,11 @@ func Test_Line_Unmarshal(t *testing.T) {
 	testUnmarshal(t, testData["line"], reflect.TypeOf(Line{}))
 }
 
+// Test_Line_MarshalJSON tests that a Line survives a round-trip through its JSON encoding, over the correct corpus.
+func Test_Line_MarshalJSON(t *testing.T) {
+	testMarshal(t, testData["line"], reflect.TypeOf(Line{}))
+}
+
 // BenchmarkLineUnmarshal benchmarks Line unmarshalling via subbenchmarks
 func BenchmarkLineUnmarshal(b *testing.B) {
 	// Get the bench data
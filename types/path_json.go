@@ -37,3 +37,19 @@ func (ps *PathSegment) UnmarshalJSON(b []byte) error {
 
 	return nil
 }
+
+// MarshalJSON implements json.Marshaller for a PathSegment
+func (ps PathSegment) MarshalJSON() ([]byte, error) {
+	data := struct {
+		Length    uint   `json:"length"`
+		Name      string `json:"name"`
+		Duration  int64  `json:"duration"`
+		Direction int    `json:"direction"`
+	}{
+		Length:    ps.Length,
+		Name:      ps.Name,
+		Duration:  int64(ps.Duration / time.Second),
+		Direction: ps.Direction,
+	}
+	return json.Marshal(data)
+}
@@ -1,7 +1,9 @@
 package types
 
 import (
+	"bytes"
 	"encoding/json"
+	"strconv"
 
 	"github.com/mb0/wkt"
 	"github.com/pkg/errors"
@@ -121,3 +123,63 @@ func convertWktMPtoGeomMP(in *wkt.MultiPolygon) (*geom.MultiPolygon, error) {
 	}
 	return mp, err
 }
+
+// MarshalJSON implements json.Marshaller for a Region
+//
+// As navitia does, the shape is encoded in WKT.
+func (r Region) MarshalJSON() ([]byte, error) {
+	data := struct {
+		ID              ID     `json:"id"`
+		Name            string `json:"name,omitempty"`
+		Status          string `json:"status,omitempty"`
+		Shape           string `json:"shape,omitempty"`
+		DatasetCreation string `json:"dataset_created_at,omitempty"`
+		LastLoaded      string `json:"last_load_at,omitempty"`
+		ProductionStart string `json:"start_production_date,omitempty"`
+		ProductionEnd   string `json:"end_production_date,omitempty"`
+		Error           string `json:"error,omitempty"`
+	}{
+		ID:              r.ID,
+		Name:            r.Name,
+		Status:          r.Status,
+		DatasetCreation: formatDateTime(r.DatasetCreation),
+		LastLoaded:      formatDateTime(r.LastLoaded),
+		ProductionStart: formatDateTime(r.ProductionStart),
+		ProductionEnd:   formatDateTime(r.ProductionEnd),
+		Error:           r.Error,
+	}
+	if r.Shape != nil {
+		data.Shape = formatWKTMultiPolygon(r.Shape)
+	}
+	return json.Marshal(data)
+}
+
+// formatWKTMultiPolygon formats a geom MultiPolygon in WKT, the inverse of parsing it then calling convertWktMPtoGeomMP
+func formatWKTMultiPolygon(mp *geom.MultiPolygon) string {
+	var buf bytes.Buffer
+	buf.WriteString("MULTIPOLYGON(")
+	for i, polygon := range mp.Coords() {
+		if i != 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteByte('(')
+		for j, ring := range polygon {
+			if j != 0 {
+				buf.WriteByte(',')
+			}
+			buf.WriteByte('(')
+			for k, coord := range ring {
+				if k != 0 {
+					buf.WriteByte(',')
+				}
+				buf.WriteString(strconv.FormatFloat(coord.X(), 'f', -1, 64))
+				buf.WriteByte(' ')
+				buf.WriteString(strconv.FormatFloat(coord.Y(), 'f', -1, 64))
+			}
+			buf.WriteByte(')')
+		}
+		buf.WriteByte(')')
+	}
+	buf.WriteByte(')')
+	return buf.String()
+}
@@ -17,6 +17,11 @@ func Test_Region_Unmarshal(t *testing.T) {
 	testUnmarshal(t, testData["region"], reflect.TypeOf(Region{}))
 }
 
+// Test_Region_MarshalJSON tests that a Region survives a round-trip through its JSON encoding, over the correct corpus.
+func Test_Region_MarshalJSON(t *testing.T) {
+	testMarshal(t, testData["region"], reflect.TypeOf(Region{}))
+}
+
 // TestRegionUnmarshal_ShapeInvalidMKT tests known invalid MKT (well-known text) -encoded Region.Shape inputs for (*Region).UnmarshalJSON
 func TestRegionUnmarshal_ShapeInvalidMKT(t *testing.T) {
 	// Shapes
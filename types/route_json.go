@@ -47,3 +47,26 @@ func (r *Route) UnmarshalJSON(b []byte) error {
 
 	return nil
 }
+
+// MarshalJSON implements json.Marshaller for Route
+//
+// As navitia does, is_frequence is encoded as "True" or "False".
+func (r Route) MarshalJSON() ([]byte, error) {
+	data := struct {
+		ID        ID         `json:"id"`
+		Name      string     `json:"name,omitempty"`
+		Frequence string     `json:"is_frequence"`
+		Line      Line       `json:"line"`
+		Direction *Container `json:"direction,omitempty"`
+	}{
+		ID:        r.ID,
+		Name:      r.Name,
+		Frequence: "False",
+		Line:      r.Line,
+		Direction: optionalContainer(r.Direction),
+	}
+	if r.Frequence {
+		data.Frequence = "True"
+	}
+	return json.Marshal(data)
+}
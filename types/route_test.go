@@ -14,3 +14,8 @@ import (
 func Test_Route_Unmarshal(t *testing.T) {
 	testUnmarshal(t, testData["route"], reflect.TypeOf(Route{}))
 }
+
+// Test_Route_MarshalJSON tests that a Route survives a round-trip through its JSON encoding, over the correct corpus.
+func Test_Route_MarshalJSON(t *testing.T) {
+	testMarshal(t, testData["route"], reflect.TypeOf(Route{}))
+}
@@ -0,0 +1,100 @@
+package types
+
+import "time"
+
+// A StopSchedule lists the date times at which the vehicles of a route stop at a stop point.
+//
+// It is also used for terminus schedules, where it lists the date times at which vehicles stop at a stop point, grouped by terminus.
+//
+// See http://doc.navitia.io/#stop-schedules
+type StopSchedule struct {
+	// Information to display
+	Display Display `json:"display_informations"`
+
+	// The stop point in question
+	StopPoint StopPoint `json:"stop_point"`
+
+	// The route in question
+	Route Route `json:"route"`
+
+	// The date times of the stops
+	DateTimes []ScheduleDateTime `json:"date_times"`
+
+	// Additional information about the schedule, for example "terminus" or "no_departure_this_day"
+	Additional string `json:"additional_informations"`
+
+	// The first and last date times of the day for this schedule
+	First ScheduleDateTime `json:"first_datetime"`
+	Last  ScheduleDateTime `json:"last_datetime"`
+}
+
+// A RouteSchedule is a timetable of a route: a table with a column per vehicle journey and a row per stop point.
+//
+// See http://doc.navitia.io/#route-schedules
+type RouteSchedule struct {
+	// Information to display
+	Display Display `json:"display_informations"`
+
+	// The timetable
+	Table ScheduleTable `json:"table"`
+
+	// Additional information about the schedule, for example "no_departure_this_day"
+	Additional string `json:"additional_informations"`
+}
+
+// A ScheduleTable is a timetable.
+//
+// Each of its row has as many date times as there are headers, the nth date time of a row being for the vehicle journey of the nth header.
+type ScheduleTable struct {
+	// The headers of the table, one per column
+	Headers []ScheduleHeader `json:"headers"`
+
+	// The rows of the table, one per stop point
+	Rows []ScheduleRow `json:"rows"`
+}
+
+// A ScheduleHeader is the header of a column in a ScheduleTable, it describes a vehicle journey.
+type ScheduleHeader struct {
+	// Information to display
+	Display Display `json:"display_informations"`
+
+	// Additional informations on the vehicle journey
+	Additional []PTMethod `json:"additional_informations"`
+}
+
+// A ScheduleRow is a row in a ScheduleTable, it lists the date times at a stop point.
+type ScheduleRow struct {
+	// The stop point of that row
+	StopPoint StopPoint `json:"stop_point"`
+
+	// The date times, one per column
+	// If a vehicle journey doesn't stop at the stop point, the corresponding date time is empty.
+	DateTimes []ScheduleDateTime `json:"date_times"`
+}
+
+// A ScheduleDateTime is a date time in a schedule.
+//
+// For calendar-based schedules, see ScheduleRequest.Calendar, navitia only gives times of day: the date times are then on the zero date, of year 0.
+type ScheduleDateTime struct {
+	// The date time of the stop, realtime if available
+	DateTime time.Time
+
+	// The date time of the stop, according to the base schedule
+	BaseDateTime time.Time
+
+	// The freshness of the date time: either realtime or base schedule
+	Freshness DataFreshness
+
+	// Additional information on this date time, for example "pick_up_only", "drop_off_only" or "date_time_estimated"
+	Additional []string
+}
+
+// Empty returns true if there is no date time, meaning that there is no stop
+func (sdt ScheduleDateTime) Empty() bool {
+	return sdt.DateTime.IsZero()
+}
+
+// Realtime reports whether the date time is computed from realtime data
+func (sdt ScheduleDateTime) Realtime() bool {
+	return sdt.Freshness == DataFreshnessRealTime
+}
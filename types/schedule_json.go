@@ -0,0 +1,84 @@
+package types
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// UnmarshalJSON implements json.Unmarshaller for a ScheduleDateTime
+func (sdt *ScheduleDateTime) UnmarshalJSON(b []byte) error {
+	// First let's create the analogous structure
+	// We define some of the value as pointers to the real values, allowing us to bypass copying in cases where we don't need to process the data
+	data := &struct {
+		// Pointers to the corresponding real values
+		Freshness  *DataFreshness `json:"data_freshness"`
+		Additional *[]string      `json:"additional_informations"`
+
+		// Values to process
+		DateTime     string `json:"date_time"`
+		BaseDateTime string `json:"base_date_time"`
+	}{
+		Freshness:  &sdt.Freshness,
+		Additional: &sdt.Additional,
+	}
+
+	// Now unmarshall the raw data into the analogous structure
+	err := json.Unmarshal(b, data)
+	if err != nil {
+		return errors.Wrap(err, "Error while unmarshalling ScheduleDateTime")
+	}
+
+	// Create the error generator
+	gen := unmarshalErrorMaker{"ScheduleDateTime", b}
+
+	// Now we use parseScheduleDateTime
+	sdt.DateTime, err = parseScheduleDateTime(data.DateTime)
+	if err != nil {
+		return gen.err(err, "DateTime", "date_time", data.DateTime, "parseScheduleDateTime failed")
+	}
+	sdt.BaseDateTime, err = parseScheduleDateTime(data.BaseDateTime)
+	if err != nil {
+		return gen.err(err, "BaseDateTime", "base_date_time", data.BaseDateTime, "parseScheduleDateTime failed")
+	}
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaller for a ScheduleDateTime
+func (sdt ScheduleDateTime) MarshalJSON() ([]byte, error) {
+	data := struct {
+		DateTime     string        `json:"date_time"`
+		BaseDateTime string        `json:"base_date_time,omitempty"`
+		Freshness    DataFreshness `json:"data_freshness,omitempty"`
+		Additional   []string      `json:"additional_informations"`
+	}{
+		DateTime:     formatScheduleDateTime(sdt.DateTime),
+		BaseDateTime: formatScheduleDateTime(sdt.BaseDateTime),
+		Freshness:    sdt.Freshness,
+		Additional:   sdt.Additional,
+	}
+	return json.Marshal(data)
+}
+
+// parseScheduleDateTime parses a date time of a schedule.
+// Calendar-based schedules only have times of day, such as "073000", which are parsed as times on the zero date, see ScheduleDateTime.
+func parseScheduleDateTime(datetime string) (time.Time, error) {
+	if len(datetime) != len(TimeFormat) {
+		return parseDateTime(datetime)
+	}
+	res, err := time.Parse(TimeFormat, datetime)
+	if err != nil {
+		err = errors.Wrap(err, "parseScheduleDateTime: error while parsing time of day")
+	}
+	return res, err
+}
+
+// formatScheduleDateTime formats a date time of a schedule, times of day being formatted as such
+func formatScheduleDateTime(datetime time.Time) string {
+	if !datetime.IsZero() && datetime.Year() == 0 {
+		return datetime.Format(TimeFormat)
+	}
+	return formatDateTime(datetime)
+}
@@ -123,3 +123,100 @@ func (ptdt *PTDateTime) UnmarshalJSON(b []byte) error {
 
 	return nil
 }
+
+// UnmarshalJSON implements json.Unmarshaller for a StopTime
+func (st *StopTime) UnmarshalJSON(b []byte) error {
+	// First let's create the analogous structure
+	// We define some of the value as pointers to the real values, allowing us to bypass copying in cases where we don't need to process the data
+	data := &struct {
+		// Pointers to the corresponding real values
+		StopPoint *StopPoint `json:"stop_point"`
+	}{
+		StopPoint: &st.StopPoint,
+	}
+
+	// Now unmarshall the raw data into the analogous structure
+	err := json.Unmarshal(b, data)
+	if err != nil {
+		return errors.Wrap(err, "Error while unmarshalling StopTime")
+	}
+
+	// The date times are at the same level as the stop point
+	err = json.Unmarshal(b, &st.PTDateTime)
+	if err != nil {
+		return errors.Wrap(err, "Error while unmarshalling StopTime")
+	}
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaller for a Section
+func (s Section) MarshalJSON() ([]byte, error) {
+	data := struct {
+		Type       SectionType       `json:"type,omitempty"`
+		ID         ID                `json:"id,omitempty"`
+		From       *Container        `json:"from,omitempty"`
+		To         *Container        `json:"to,omitempty"`
+		Mode       string            `json:"mode,omitempty"`
+		Departure  string            `json:"departure_date_time,omitempty"`
+		Arrival    string            `json:"arrival_date_time,omitempty"`
+		Duration   int64             `json:"duration"`
+		Path       []PathSegment     `json:"path"`
+		Geo        *geojson.Geometry `json:"geojson,omitempty"`
+		StopTimes  []StopTime        `json:"stop_date_times"`
+		Display    Display           `json:"display_informations"`
+		Additional []PTMethod        `json:"additional_informations"`
+	}{
+		Type:       s.Type,
+		ID:         s.ID,
+		From:       optionalContainer(s.From),
+		To:         optionalContainer(s.To),
+		Mode:       s.Mode,
+		Departure:  formatDateTime(s.Departure),
+		Arrival:    formatDateTime(s.Arrival),
+		Duration:   int64(s.Duration / time.Second),
+		Path:       s.Path,
+		StopTimes:  s.StopTimes,
+		Display:    s.Display,
+		Additional: s.Additional,
+	}
+
+	// Encode the geometry
+	if s.Geo != nil {
+		geo, err := geojson.Encode(s.Geo)
+		if err != nil {
+			return nil, errors.Wrap(err, "Section.MarshalJSON: error while encoding Geo")
+		}
+		data.Geo = geo
+	}
+
+	return json.Marshal(data)
+}
+
+// MarshalJSON implements json.Marshaller for a PTDateTime
+func (ptdt PTDateTime) MarshalJSON() ([]byte, error) {
+	data := struct {
+		Departure string `json:"departure_date_time,omitempty"`
+		Arrival   string `json:"arrival_date_time,omitempty"`
+	}{
+		Departure: formatDateTime(ptdt.Departure),
+		Arrival:   formatDateTime(ptdt.Arrival),
+	}
+	return json.Marshal(data)
+}
+
+// MarshalJSON implements json.Marshaller for a StopTime
+//
+// As navitia does, the date times are encoded at the same level as the stop point.
+func (st StopTime) MarshalJSON() ([]byte, error) {
+	data := struct {
+		Departure string    `json:"departure_date_time,omitempty"`
+		Arrival   string    `json:"arrival_date_time,omitempty"`
+		StopPoint StopPoint `json:"stop_point"`
+	}{
+		Departure: formatDateTime(st.PTDateTime.Departure),
+		Arrival:   formatDateTime(st.PTDateTime.Arrival),
+		StopPoint: st.StopPoint,
+	}
+	return json.Marshal(data)
+}
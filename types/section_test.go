@@ -14,3 +14,8 @@ import (
 func Test_Section_Unmarshal(t *testing.T) {
 	testUnmarshal(t, testData["section"], reflect.TypeOf(Section{}))
 }
+
+// Test_Section_MarshalJSON tests that a Section survives a round-trip through its JSON encoding, over the correct corpus.
+func Test_Section_MarshalJSON(t *testing.T) {
+	testMarshal(t, testData["section"], reflect.TypeOf(Section{}))
+}
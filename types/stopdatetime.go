@@ -0,0 +1,45 @@
+package types
+
+import "time"
+
+// A StopDateTime stores the times of a vehicle at a stop, as found in departures and arrivals.
+//
+// See http://doc.navitia.io/#departures
+type StopDateTime struct {
+	// The date times of the departure & arrival, realtime if available
+	Departure time.Time
+	Arrival   time.Time
+
+	// The date times of the departure & arrival, according to the base schedule
+	BaseDeparture time.Time
+	BaseArrival   time.Time
+
+	// The freshness of the date times: either realtime or base schedule
+	Freshness DataFreshness
+
+	// Additional information on the stop, for example "pick_up_only", "drop_off_only" or "date_time_estimated"
+	Additional []string
+
+	// Disruptions lists the IDs of the disruptions impacting the stop
+	Disruptions []ID
+}
+
+// Delay returns the deviation of the stop from the base schedule: positive if late, negative if early.
+//
+// It is computed on the departure, or on the arrival if there is no departure.
+// If the base schedule is unknown, the delay is 0.
+func (sdt StopDateTime) Delay() time.Duration {
+	actual, base := sdt.Departure, sdt.BaseDeparture
+	if actual.IsZero() {
+		actual, base = sdt.Arrival, sdt.BaseArrival
+	}
+	if actual.IsZero() || base.IsZero() {
+		return 0
+	}
+	return actual.Sub(base)
+}
+
+// Realtime reports whether the date times are computed from realtime data
+func (sdt StopDateTime) Realtime() bool {
+	return sdt.Freshness == DataFreshnessRealTime
+}
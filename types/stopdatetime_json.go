@@ -0,0 +1,99 @@
+package types
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// UnmarshalJSON implements json.Unmarshaller for a StopDateTime
+func (sdt *StopDateTime) UnmarshalJSON(b []byte) error {
+	// First let's create the analogous structure
+	// We define some of the value as pointers to the real values, allowing us to bypass copying in cases where we don't need to process the data
+	data := &struct {
+		// Pointers to the corresponding real values
+		Freshness  *DataFreshness `json:"data_freshness"`
+		Additional *[]string      `json:"additional_informations"`
+
+		// Values to process
+		Departure     string `json:"departure_date_time"`
+		Arrival       string `json:"arrival_date_time"`
+		BaseDeparture string `json:"base_departure_date_time"`
+		BaseArrival   string `json:"base_arrival_date_time"`
+		Links         []struct {
+			Type string `json:"type"`
+			ID   ID     `json:"id"`
+		} `json:"links"`
+	}{
+		Freshness:  &sdt.Freshness,
+		Additional: &sdt.Additional,
+	}
+
+	// Now unmarshall the raw data into the analogous structure
+	err := json.Unmarshal(b, data)
+	if err != nil {
+		return errors.Wrap(err, "Error while unmarshalling StopDateTime")
+	}
+
+	// Create the error generator
+	gen := unmarshalErrorMaker{"StopDateTime", b}
+
+	// Now we use parseDateTime
+	sdt.Departure, err = parseDateTime(data.Departure)
+	if err != nil {
+		return gen.err(err, "Departure", "departure_date_time", data.Departure, "parseDateTime failed")
+	}
+	sdt.Arrival, err = parseDateTime(data.Arrival)
+	if err != nil {
+		return gen.err(err, "Arrival", "arrival_date_time", data.Arrival, "parseDateTime failed")
+	}
+	sdt.BaseDeparture, err = parseDateTime(data.BaseDeparture)
+	if err != nil {
+		return gen.err(err, "BaseDeparture", "base_departure_date_time", data.BaseDeparture, "parseDateTime failed")
+	}
+	sdt.BaseArrival, err = parseDateTime(data.BaseArrival)
+	if err != nil {
+		return gen.err(err, "BaseArrival", "base_arrival_date_time", data.BaseArrival, "parseDateTime failed")
+	}
+
+	// Retrieve the disruptions from the links
+	sdt.Disruptions = nil
+	for _, l := range data.Links {
+		if l.Type == "disruption" {
+			sdt.Disruptions = append(sdt.Disruptions, l.ID)
+		}
+	}
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaller for a StopDateTime
+//
+// As navitia does, the disruptions are encoded as links.
+func (sdt StopDateTime) MarshalJSON() ([]byte, error) {
+	type link struct {
+		Type string `json:"type"`
+		ID   ID     `json:"id"`
+	}
+	data := struct {
+		Departure     string        `json:"departure_date_time,omitempty"`
+		Arrival       string        `json:"arrival_date_time,omitempty"`
+		BaseDeparture string        `json:"base_departure_date_time,omitempty"`
+		BaseArrival   string        `json:"base_arrival_date_time,omitempty"`
+		Freshness     DataFreshness `json:"data_freshness,omitempty"`
+		Additional    []string      `json:"additional_informations"`
+		Links         []link        `json:"links"`
+	}{
+		Departure:     formatDateTime(sdt.Departure),
+		Arrival:       formatDateTime(sdt.Arrival),
+		BaseDeparture: formatDateTime(sdt.BaseDeparture),
+		BaseArrival:   formatDateTime(sdt.BaseArrival),
+		Freshness:     sdt.Freshness,
+		Additional:    sdt.Additional,
+		Links:         make([]link, len(sdt.Disruptions)),
+	}
+	for i, id := range sdt.Disruptions {
+		data.Links[i] = link{Type: "disruption", ID: id}
+	}
+	return json.Marshal(data)
+}
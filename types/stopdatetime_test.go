@@ -0,0 +1,52 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// TestStopDateTime_UnmarshalJSON checks the parsing of a realtime StopDateTime, and its delay
+func TestStopDateTime_UnmarshalJSON(t *testing.T) {
+	input := []byte(`{
+		"additional_informations": ["date_time_estimated"],
+		"departure_date_time": "20170427T170700",
+		"base_departure_date_time": "20170427T170500",
+		"arrival_date_time": "20170427T170630",
+		"base_arrival_date_time": "20170427T170430",
+		"data_freshness": "realtime",
+		"links": [
+			{"type": "disruption", "id": "d6d0bc6c-2a62-11e7-a1f4-005056a47b86", "internal": true, "rel": "disruptions", "templated": false}
+		]
+	}`)
+
+	var sdt StopDateTime
+	err := json.Unmarshal(input, &sdt)
+	if err != nil {
+		t.Fatalf("Error while unmarshalling: %v", err)
+	}
+
+	if !sdt.Realtime() {
+		t.Errorf("Expected a realtime StopDateTime, got freshness %q", sdt.Freshness)
+	}
+	if len(sdt.Additional) != 1 || sdt.Additional[0] != "date_time_estimated" {
+		t.Errorf("Unexpected additional informations: %v", sdt.Additional)
+	}
+	if len(sdt.Disruptions) != 1 || sdt.Disruptions[0] != "d6d0bc6c-2a62-11e7-a1f4-005056a47b86" {
+		t.Errorf("Unexpected disruptions: %v", sdt.Disruptions)
+	}
+	if delay := sdt.Delay(); delay != 2*time.Minute {
+		t.Errorf("Expected a delay of 2m0s, got %v", delay)
+	}
+
+	// Without departure, the delay is computed on the arrival
+	sdt.Departure = time.Time{}
+	if delay := sdt.Delay(); delay != 2*time.Minute {
+		t.Errorf("Expected a delay of 2m0s on the arrival, got %v", delay)
+	}
+
+	// Without base schedule, there is no delay
+	if delay := (StopDateTime{Departure: sdt.Arrival}).Delay(); delay != 0 {
+		t.Errorf("Expected no delay without base schedule, got %v", delay)
+	}
+}
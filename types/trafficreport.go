@@ -3,15 +3,52 @@ package types
 // A TrafficReport made of a network, an array of lines and an array of stop_areas.
 // Named "traffic_report" in the Navitia doc
 //
+// The internal links to disruptions sent by navitia are resolved, each object being accompanied by the disruptions impacting it.
+//
 // See http://doc.navitia.io/#traffic-reports
-// TODO: Add the internal links
 type TrafficReport struct {
-	// Main object (network) and links within its own disruptions
-	Network Network
+	// Main object (network)
+	Network Network `json:"network"`
+
+	// The disruptions impacting the network as a whole
+	Disruptions []Disruption `json:"disruptions"`
 
 	// List of all disrupted Lines from the network
-	Lines []Line
+	Lines []DisruptedLine `json:"lines"`
 
 	// List of all disrupted StopAreas from the network
-	StopAreas []StopArea
+	StopAreas []DisruptedStopArea `json:"stop_areas"`
+}
+
+// A LineReport is made of a line and the public transport objects of that line which are disrupted.
+// Named "line_report" in the Navitia doc
+//
+// See http://doc.navitia.io/#line-reports
+type LineReport struct {
+	// Main object (line)
+	Line Line `json:"line"`
+
+	// The disruptions impacting the line as a whole
+	Disruptions []Disruption `json:"disruptions"`
+
+	// List of all disrupted objects of the line
+	PTObjects []DisruptedObject `json:"pt_objects"`
+}
+
+// A DisruptedLine is a Line along with the disruptions impacting it
+type DisruptedLine struct {
+	Line        Line         `json:"line"`
+	Disruptions []Disruption `json:"disruptions"`
+}
+
+// A DisruptedStopArea is a StopArea along with the disruptions impacting it
+type DisruptedStopArea struct {
+	StopArea    StopArea     `json:"stop_area"`
+	Disruptions []Disruption `json:"disruptions"`
+}
+
+// A DisruptedObject is a Container holding a PTObject, along with the disruptions impacting it
+type DisruptedObject struct {
+	Object      Container    `json:"pt_object"`
+	Disruptions []Disruption `json:"disruptions"`
 }
@@ -60,6 +60,7 @@ var typesList = []string{
 	"line",
 	"network",
 	"company",
+	"isochrone",
 }
 
 // listCategoryDirs retrieves the subdirectories under the main testdata directory
@@ -0,0 +1,37 @@
+package types
+
+import "time"
+
+// A VehicleJourney is a public transport vehicle circulation, on a given day, along a Route.
+//
+// See http://doc.navitia.io/#public-transport-objects
+type VehicleJourney struct {
+	// Identifier of the vehicle journey
+	ID ID `json:"id"`
+
+	// Name of the vehicle journey
+	Name string `json:"name"`
+
+	// The headsign of the vehicle journey
+	Headsign string `json:"headsign"`
+
+	// The trip the vehicle journey is a circulation of
+	Trip Trip `json:"trip"`
+
+	// The stop times of the vehicle journey
+	StopTimes []VehicleJourneyStopTime `json:"stop_times"`
+}
+
+// A VehicleJourneyStopTime is a stop of a VehicleJourney at a StopPoint.
+type VehicleJourneyStopTime struct {
+	// The stop point in question
+	StopPoint StopPoint
+
+	// Arrival and Departure are the time of arrival and departure at the stop point, counted from the start of the day of circulation.
+	// As such, they can be greater than 24 hours.
+	Arrival   time.Duration
+	Departure time.Duration
+
+	// The headsign of the vehicle at this stop
+	Headsign string
+}
@@ -0,0 +1,101 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// UnmarshalJSON implements json.Unmarshaller for a VehicleJourneyStopTime
+func (st *VehicleJourneyStopTime) UnmarshalJSON(b []byte) error {
+	// First let's create the analogous structure
+	// We define some of the value as pointers to the real values, allowing us to bypass copying in cases where we don't need to process the data
+	data := &struct {
+		// Pointers to the corresponding real values
+		StopPoint *StopPoint `json:"stop_point"`
+		Headsign  *string    `json:"headsign"`
+
+		// Values to process
+		Arrival   string `json:"arrival_time"`
+		Departure string `json:"departure_time"`
+	}{
+		StopPoint: &st.StopPoint,
+		Headsign:  &st.Headsign,
+	}
+
+	// Now unmarshall the raw data into the analogous structure
+	err := json.Unmarshal(b, data)
+	if err != nil {
+		return errors.Wrap(err, "Error while unmarshalling VehicleJourneyStopTime")
+	}
+
+	// Create the error generator
+	gen := unmarshalErrorMaker{"VehicleJourneyStopTime", b}
+
+	// Now process the times
+	st.Arrival, err = parseDayTime(data.Arrival)
+	if err != nil {
+		return gen.err(err, "Arrival", "arrival_time", data.Arrival, "parseDayTime failed")
+	}
+	st.Departure, err = parseDayTime(data.Departure)
+	if err != nil {
+		return gen.err(err, "Departure", "departure_time", data.Departure, "parseDayTime failed")
+	}
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaller for a VehicleJourneyStopTime
+func (st VehicleJourneyStopTime) MarshalJSON() ([]byte, error) {
+	data := struct {
+		StopPoint StopPoint `json:"stop_point"`
+		Headsign  string    `json:"headsign,omitempty"`
+		Arrival   string    `json:"arrival_time"`
+		Departure string    `json:"departure_time"`
+	}{
+		StopPoint: st.StopPoint,
+		Headsign:  st.Headsign,
+		Arrival:   formatDayTime(st.Arrival),
+		Departure: formatDayTime(st.Departure),
+	}
+	return json.Marshal(data)
+}
+
+// parseDayTime parses a time of the day formatted as HHMMSS into the duration since the start of the day.
+// Hours can go over 24, as a vehicle journey can end after midnight.
+// If the given string is empty (i.e ""), then zero is returned.
+func parseDayTime(str string) (time.Duration, error) {
+	if str == "" {
+		return 0, nil
+	}
+	if len(str) != 6 {
+		return 0, errors.Errorf("parseDayTime: time string not to standard: len=%d instead of 6", len(str))
+	}
+
+	h, err := strconv.ParseUint(str[:2], 10, 8)
+	if err != nil {
+		return 0, errors.Wrap(err, "parseDayTime: error while parsing hours")
+	}
+	m, err := strconv.ParseUint(str[2:4], 10, 8)
+	if err != nil {
+		return 0, errors.Wrap(err, "parseDayTime: error while parsing minutes")
+	}
+	s, err := strconv.ParseUint(str[4:], 10, 8)
+	if err != nil {
+		return 0, errors.Wrap(err, "parseDayTime: error while parsing seconds")
+	}
+
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(s)*time.Second, nil
+}
+
+// formatDayTime formats the duration since the start of the day as HHMMSS, the inverse of parseDayTime.
+func formatDayTime(d time.Duration) string {
+	d = d.Truncate(time.Second)
+	h := d / time.Hour
+	m := (d % time.Hour) / time.Minute
+	s := (d % time.Minute) / time.Second
+	return fmt.Sprintf("%02d%02d%02d", h, m, s)
+}
@@ -0,0 +1,29 @@
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+// Test_parseDayTime tests parseDayTime with known inputs, including times after midnight
+func Test_parseDayTime(t *testing.T) {
+	known := map[string]time.Duration{
+		"":       0,
+		"053000": 5*time.Hour + 30*time.Minute,
+		"240130": 24*time.Hour + time.Minute + 30*time.Second,
+	}
+	for in, expected := range known {
+		got, err := parseDayTime(in)
+		if err != nil {
+			t.Errorf("parseDayTime(%q): unexpected error: %v", in, err)
+		} else if got != expected {
+			t.Errorf("parseDayTime(%q): expected %v, got %v", in, expected, got)
+		}
+	}
+
+	for _, in := range []string{"0530", "05h30m", "0530000"} {
+		if _, err := parseDayTime(in); err == nil {
+			t.Errorf("parseDayTime(%q): expected an error, got none", in)
+		}
+	}
+}